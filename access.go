@@ -15,6 +15,19 @@ import (
 	"time"
 )
 
+// access-control sql-scripts (%v: access-table name), on the column-names of the built-in AccessMigrations tables
+const (
+	serviceAccessScript = "SELECT id, category from %v WHERE name=$1"
+	roleServicesScript  = "SELECT id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud from %v WHERE service_id = ANY($1) AND role_id = ANY($2) AND is_active=$3"
+	accessExpireScript  = "SELECT expire from %v WHERE user_id=$1 AND token=$2 AND login_name=$3"
+	userAccessScript    = "SELECT id, groups, is_admin, is_active from %v WHERE id=$1 AND is_active=$2"
+	profileGroupScript  = `SELECT "group" from %v WHERE user_id=$1 AND is_active=$2`
+	appAccessScript     = "SELECT app_name from %v WHERE app_id=$1 AND access_key=$2 AND is_active=$3"
+	roleInheritScript   = "SELECT role_id, parent_id from %v WHERE role_id = ANY($1) AND is_active=$2"
+	userRolesScript     = "SELECT groups, is_admin from %v WHERE id=$1"
+	serviceIdScript     = "SELECT id from %v WHERE name=$1"
+)
+
 // AccessInfoType for CheckUserAccess method value (interface{}) response,
 // and to assert returned value
 type AccessInfoType struct {
@@ -23,6 +36,7 @@ type AccessInfoType struct {
 	RoleIds  []string
 	IsAdmin  bool
 	IsActive bool
+	Expire   int64 // access-token expiry (unix-ms)
 }
 
 // TaskPermissionType for TaskPermission method value (interface{}) response,
@@ -310,6 +324,28 @@ func (crud *Crud) TaskPermission(taskType string) mcresponse.ResponseMessage {
 
 // CheckTaskAccess method determines the access by role-assignment
 func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
+	// check the cached access-decision
+	cacheKey := AccessCacheKey(crud.UserInfo.UserId, crud.UserInfo.Token, crud.TableName, crud.RecordIds)
+	if crud.CacheAccess {
+		if cachedRes, ok := GetAccessCache(cacheKey); ok {
+			// sliding-expiry: extend the access-token expiry, near the expiry of the (cached) access
+			if crud.TokenRefreshDue(cachedRes.TokenExpire) {
+				refreshRes := crud.RefreshToken(crud.UserInfo.Token)
+				if refreshRes.Code != "success" {
+					InvalidateAccessCache(crud.UserInfo.UserId)
+					return refreshRes
+				}
+				if val, ok := refreshRes.Value.(LoginResultType); ok {
+					cachedRes.TokenExpire = val.Expire
+					SetAccessCache(cacheKey, cachedRes, crud.CacheExpire, val.Expire)
+				}
+			}
+			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+				Message: "Action authorised / permitted.",
+				Value:   cachedRes,
+			})
+		}
+	}
 	// validate current user active status: by token (API) and user/loggedIn-status
 	accessRes := crud.CheckUserAccess()
 	if accessRes.Code != "success" {
@@ -324,7 +360,9 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 		isAdmin  bool
 		isActive bool
 	)
+	var tokenExpire int64
 	if val, ok := accessRes.Value.(AccessInfoType); ok {
+		tokenExpire = val.Expire
 		uId = val.UserId
 		roleId = val.RoleId
 		roleIds = val.RoleIds
@@ -343,7 +381,7 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 		serviceId string
 		category  string
 	)
-	serviceScript := fmt.Sprintf("SELECT id, category from %v WHERE name=$1", crud.ServiceTable)
+	serviceScript := fmt.Sprintf(serviceAccessScript, crud.ServiceTable)
 	serviceRow := crud.AccessDb.QueryRow(context.Background(), serviceScript, crud.TableName)
 	// check error
 	if err := serviceRow.Scan(&serviceId, &category); err != nil {
@@ -361,6 +399,18 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 		serviceIds = append(serviceIds, serviceId)
 	}
 
+	// include the inherited (parent) roles
+	if crud.RoleInheritance && len(roleIds) > 0 {
+		allRoleIds, riErr := crud.ResolveRoleIds(roleIds)
+		if riErr != nil {
+			return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error resolving inherited roles | %v", riErr.Error()),
+				Value:   nil,
+			})
+		}
+		roleIds = allRoleIds
+	}
+
 	var roleServices []RoleServiceType
 	var rsErr error
 	if len(serviceIds) > 0 {
@@ -381,6 +431,10 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 		IsAdmin:      isAdmin,
 		RoleServices: roleServices,
 		TableId:      tableId,
+		TokenExpire:  tokenExpire,
+	}
+	if crud.CacheAccess {
+		SetAccessCache(cacheKey, permittedRes, crud.CacheExpire, tokenExpire)
 	}
 
 	// if all went well
@@ -393,26 +447,8 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 // GetRoleServices method process and returns the permission to user / user-group for the specified service items
 func (crud *Crud) GetRoleServices(accessDb *pgxpool.Pool, roleTable string, roleIds []string, serviceIds []string) ([]RoleServiceType, error) {
 	var roleServices []RoleServiceType
-	roleScript := fmt.Sprintf("SELECT id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud from %v WHERE service_id IN ($1) AND role_id IN ($2) AND is_active=$3", roleTable)
-	// where-in-values - serviceIds and roleIds
-	inValues := ""
-	idLen := len(serviceIds)
-	for idCount, id := range serviceIds {
-		inValues += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			inValues += ", "
-		}
-	}
-	inRoleValues := ""
-	roleIdLen := len(roleIds)
-	for roleIdCount, id := range roleIds {
-		inRoleValues += "'" + id + "'"
-		if roleIdLen > 1 && roleIdCount < roleIdLen-1 {
-			inRoleValues += ", "
-		}
-	}
-
-	rows, err := accessDb.Query(context.Background(), roleScript, inValues, inRoleValues, true)
+	roleScript := fmt.Sprintf(roleServicesScript, roleTable)
+	rows, err := accessDb.Query(context.Background(), roleScript, serviceIds, roleIds, true)
 	if err != nil {
 		//errMsg := fmt.Sprintf("Db query Error: %v", err.Error())
 		return roleServices, errors.New(fmt.Sprintf("%v", err.Error()))
@@ -445,7 +481,7 @@ func (crud *Crud) GetRoleServices(accessDb *pgxpool.Pool, roleTable string, role
 func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 	// validate current user active status: by token (API) and user/loggedIn-status
 	// get the accessKey information for the user
-	accessScript := fmt.Sprintf("SELECT expire from %v WHERE user_id=$1 AND token=$2 AND login_name=$3", crud.AccessTable)
+	accessScript := fmt.Sprintf(accessExpireScript, crud.AccessTable)
 	rowAccess := crud.AccessDb.QueryRow(context.Background(), accessScript, crud.UserInfo.UserId, crud.UserInfo.Token, crud.UserInfo.LoginName)
 	// check login-status/expiration
 	var accessExpire int64
@@ -462,6 +498,14 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 			})
 		}
 	}
+	// sliding-expiry: extend the access-token expiry, near the expiry
+	if crud.TokenRefreshDue(accessExpire) {
+		if refreshRes := crud.RefreshToken(crud.UserInfo.Token); refreshRes.Code == "success" {
+			if val, ok := refreshRes.Value.(LoginResultType); ok {
+				accessExpire = val.Expire
+			}
+		}
+	}
 	// check the current-user status/info
 	var (
 		uId      string
@@ -470,7 +514,7 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 		isAdmin  bool
 		isActive bool
 	)
-	userScript := fmt.Sprintf("SELECT id, groups, isAdmin, isActive from %v WHERE id=$1 AND is_active=$2", crud.UserTable)
+	userScript := fmt.Sprintf(userAccessScript, crud.UserTable)
 	rowUser := crud.AccessDb.QueryRow(context.Background(), userScript, crud.UserInfo.UserId, true)
 	if err := rowUser.Scan(&uId, &groups, &isAdmin, &isActive); err != nil {
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
@@ -479,7 +523,7 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 		})
 	}
 	// get default-group from user profile
-	pScript := fmt.Sprintf("SELECT group from %v WHERE user_id=$1 is_active=$2", crud.ProfileTable)
+	pScript := fmt.Sprintf(profileGroupScript, crud.ProfileTable)
 	userProfile := crud.AccessDb.QueryRow(context.Background(), pScript, crud.UserInfo.UserId, true)
 	if err := userProfile.Scan(&group); err != nil {
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
@@ -497,6 +541,7 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 			RoleIds:  groups,
 			IsAdmin:  isAdmin,
 			IsActive: isActive,
+			Expire:   accessExpire,
 		},
 	})
 }
@@ -563,3 +608,31 @@ func (crud *Crud) CheckLoginStatus(params UserInfoType) mcresponse.ResponseMessa
 		Value:   uId,
 	})
 }
+
+// CheckAppAccess method validates the app/tenant access by appId and accessKey, from the appTable
+func (crud *Crud) CheckAppAccess() mcresponse.ResponseMessage {
+	if crud.AppParams.AppId == "" || crud.AppParams.AccessKey == "" {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: app-id and access-key are required",
+			Value:   nil,
+		})
+	}
+	var appName string
+	appScript := fmt.Sprintf(appAccessScript, crud.AppTable)
+	appRow := crud.AccessDb.QueryRow(context.Background(), appScript, crud.AppParams.AppId, crud.AppParams.AccessKey, true)
+	if err := appRow.Scan(&appName); err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: app information not found, inactive or invalid access-key for app-id: %v", crud.AppParams.AppId),
+			Value:   nil,
+		})
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Action authorised / App access permitted.",
+		Value: AppParamsType{
+			AppId:   crud.AppParams.AppId,
+			AppName: appName,
+		},
+	})
+}
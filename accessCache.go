@@ -0,0 +1,141 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud - access-decision cache
+
+package mcgorm
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type accessCacheItem struct {
+	userId string
+	value  CheckAccessType
+	expire time.Time
+}
+
+// AccessCacheMaxSize is the maximum number of the cached access-decisions: the expired, then the earliest expiring,
+// access-decisions are evicted at the limit
+var AccessCacheMaxSize = 10000
+
+// AccessCacheSweepInterval is the minimum interval of the expired access-decisions sweep, on the cache writes
+var AccessCacheSweepInterval = time.Minute
+
+var (
+	accessCacheMutex sync.RWMutex
+	accessCache      = map[string]accessCacheItem{}
+	accessCacheSweep time.Time // last expired access-decisions sweep
+)
+
+// AccessCacheKey returns the access-decision cache-key, by user, token, table and record-ids
+func AccessCacheKey(userId string, token string, tableName string, recordIds []string) string {
+	return strings.Join([]string{userId, token, tableName, strings.Join(recordIds, ",")}, "|")
+}
+
+// GetAccessCache returns the un-expired cached access-decision for the cacheKey
+func GetAccessCache(cacheKey string) (CheckAccessType, bool) {
+	accessCacheMutex.RLock()
+	item, ok := accessCache[cacheKey]
+	accessCacheMutex.RUnlock()
+	if !ok {
+		return CheckAccessType{}, false
+	}
+	if time.Now().After(item.expire) {
+		accessCacheMutex.Lock()
+		delete(accessCache, cacheKey)
+		accessCacheMutex.Unlock()
+		return CheckAccessType{}, false
+	}
+	return item.value, true
+}
+
+// SetAccessCache caches the access-decision for the cacheKey, for expire seconds, capped at the access-token
+// expiry (tokenExpire, unix-ms; no cap, if 0). The expired access-decisions are swept periodically, and the cache
+// size is capped at AccessCacheMaxSize.
+func SetAccessCache(cacheKey string, value CheckAccessType, expire int, tokenExpire int64) {
+	if expire <= 0 {
+		expire = 300
+	}
+	now := time.Now()
+	expireAt := now.Add(time.Duration(expire) * time.Second)
+	if tokenExpire > 0 {
+		if tokenExpireAt := time.Unix(0, tokenExpire*int64(time.Millisecond)); tokenExpireAt.Before(expireAt) {
+			expireAt = tokenExpireAt
+		}
+	}
+	if !expireAt.After(now) {
+		// expired access-token: not cached
+		return
+	}
+	accessCacheMutex.Lock()
+	defer accessCacheMutex.Unlock()
+	if now.Sub(accessCacheSweep) >= AccessCacheSweepInterval || len(accessCache) >= AccessCacheMaxSize {
+		sweepAccessCache(now)
+	}
+	if _, ok := accessCache[cacheKey]; !ok {
+		for AccessCacheMaxSize > 0 && len(accessCache) >= AccessCacheMaxSize {
+			evictAccessCache()
+		}
+	}
+	accessCache[cacheKey] = accessCacheItem{
+		userId: value.UserId,
+		value:  value,
+		expire: expireAt,
+	}
+}
+
+// sweepAccessCache removes the expired access-decisions (accessCacheMutex locked by the caller)
+func sweepAccessCache(now time.Time) {
+	for key, item := range accessCache {
+		if now.After(item.expire) {
+			delete(accessCache, key)
+		}
+	}
+	accessCacheSweep = now
+}
+
+// evictAccessCache removes the earliest expiring access-decision (accessCacheMutex locked by the caller)
+func evictAccessCache() {
+	var evictKey string
+	var evictExpire time.Time
+	found := false
+	for key, item := range accessCache {
+		if !found || item.expire.Before(evictExpire) {
+			evictKey = key
+			evictExpire = item.expire
+			found = true
+		}
+	}
+	if found {
+		delete(accessCache, evictKey)
+	}
+}
+
+// InvalidateAccessCache removes the cached access-decisions of the user, e.g. on logout or access-keys changes
+func InvalidateAccessCache(userId string) {
+	accessCacheMutex.Lock()
+	defer accessCacheMutex.Unlock()
+	for key, item := range accessCache {
+		if item.userId == userId {
+			delete(accessCache, key)
+		}
+	}
+}
+
+// ClearAccessCache removes all the cached access-decisions, e.g. on roles or services changes
+func ClearAccessCache() {
+	accessCacheMutex.Lock()
+	accessCache = map[string]accessCacheItem{}
+	accessCacheMutex.Unlock()
+}
+
+// InvalidateTableAccessCache method clears the cached access-decisions, if the crud-table is an access-control table
+// (users, profiles, roles, role-inheritance, services or access-keys)
+func (crud *Crud) InvalidateTableAccessCache() {
+	accessTables := []string{crud.UserTable, crud.ProfileTable, crud.RoleTable, crud.RoleInheritTable, crud.ServiceTable, crud.AccessTable}
+	if ArrayStringContains(accessTables, crud.TableName) {
+		ClearAccessCache()
+	}
+}
@@ -0,0 +1,83 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access-decision cache test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestAccessCache(t *testing.T) {
+	unixMs := func(at time.Time) int64 {
+		return at.UnixNano() / int64(time.Millisecond)
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache and invalidate the user access-decisions:",
+		TestFunc: func() {
+			ClearAccessCache()
+			cacheKey := AccessCacheKey("user-100", "token-100", CategoryTable, nil)
+			SetAccessCache(cacheKey, CheckAccessType{UserId: "user-100"}, 300, 0)
+			value, ok := GetAccessCache(cacheKey)
+			mctest.AssertEquals(t, ok, true, "cached access-decision should be: true")
+			mctest.AssertEquals(t, value.UserId, "user-100", "cached userId should be: user-100")
+			InvalidateAccessCache("user-100")
+			_, ok = GetAccessCache(cacheKey)
+			mctest.AssertEquals(t, ok, false, "invalidated access-decision should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cap the cache-expiry at the access-token expiry:",
+		TestFunc: func() {
+			ClearAccessCache()
+			expiredKey := AccessCacheKey("user-100", "token-expired", CategoryTable, nil)
+			SetAccessCache(expiredKey, CheckAccessType{UserId: "user-100"}, 300, unixMs(time.Now().Add(-time.Second)))
+			_, ok := GetAccessCache(expiredKey)
+			mctest.AssertEquals(t, ok, false, "expired-token access-decision should be: false")
+			cacheKey := AccessCacheKey("user-100", "token-100", CategoryTable, nil)
+			SetAccessCache(cacheKey, CheckAccessType{UserId: "user-100"}, 300, unixMs(time.Now().Add(50*time.Millisecond)))
+			_, ok = GetAccessCache(cacheKey)
+			mctest.AssertEquals(t, ok, true, "un-expired token access-decision should be: true")
+			time.Sleep(60 * time.Millisecond)
+			_, ok = GetAccessCache(cacheKey)
+			mctest.AssertEquals(t, ok, false, "token-expired access-decision should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should sweep the expired access-decisions and cap the cache size:",
+		TestFunc: func() {
+			ClearAccessCache()
+			maxSize, sweepInterval := AccessCacheMaxSize, AccessCacheSweepInterval
+			defer func() {
+				AccessCacheMaxSize, AccessCacheSweepInterval = maxSize, sweepInterval
+			}()
+			AccessCacheSweepInterval = 0
+			SetAccessCache("key-expiring", CheckAccessType{}, 300, unixMs(time.Now().Add(20*time.Millisecond)))
+			time.Sleep(30 * time.Millisecond)
+			SetAccessCache("key-100", CheckAccessType{}, 300, 0)
+			mctest.AssertEquals(t, len(accessCache), 1, "swept cache size should be: 1")
+			AccessCacheMaxSize = 3
+			for _, key := range []string{"key-200", "key-300", "key-400", "key-500"} {
+				SetAccessCache(key, CheckAccessType{}, 300, 0)
+			}
+			mctest.AssertEquals(t, len(accessCache), 3, "capped cache size should be: 3")
+			_, ok := GetAccessCache("key-500")
+			mctest.AssertEquals(t, ok, true, "latest access-decision should be: cached")
+			ClearAccessCache()
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refresh the sliding-expiry access-token, near the expiry only:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{}, CrudOptionsType{SlidingExpiry: true, LoginTimeout: 3600})
+			mctest.AssertEquals(t, crud.TokenRefreshDue(unixMs(time.Now().Add(50*time.Minute))), false, "fresh token refresh should be: false")
+			mctest.AssertEquals(t, crud.TokenRefreshDue(unixMs(time.Now().Add(10*time.Minute))), true, "expiring token refresh should be: true")
+			crud.SlidingExpiry = false
+			mctest.AssertEquals(t, crud.TokenRefreshDue(unixMs(time.Now().Add(10*time.Minute))), false, "fixed-expiry token refresh should be: false")
+		},
+	})
+
+	mctest.PostTestResult()
+}
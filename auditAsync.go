@@ -0,0 +1,163 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - asynchronous, batched writer
+
+package mcgorm
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AsyncAuditOptionsType set the asynchronous audit-log writer optional parameters
+type AsyncAuditOptionsType struct {
+	QueueSize     int                             // bounded queue size, default: 10000
+	BatchSize     int                             // max entries per batch-insert, default: 100
+	FlushInterval time.Duration                   // max wait before a partial batch is written, default: 1s
+	MaxRetries    int                             // batch-insert retries, default: 3, negative: no retries
+	RetryBackoff  time.Duration                   // initial retry wait, doubled for each retry, default: 100ms
+	OnError       func(err error, audits []Audit) // called for the batch dropped after the retries
+}
+
+// AsyncAuditLog is the asynchronous, batched audit-log writer
+type AsyncAuditLog struct {
+	LogParam
+	AsyncAuditOptionsType
+	queue    chan Audit
+	dropped  int64
+	closed   bool
+	mutex    sync.RWMutex
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewAsyncAuditLog constructor returns a new asynchronous audit-log writer, and starts the writer goroutine.
+// The entries are inserted into the auditLog table (and hash-chained, if enabled).
+func NewAsyncAuditLog(auditLog LogParam, options AsyncAuditOptionsType) *AsyncAuditLog {
+	result := &AsyncAuditLog{}
+	result.LogParam = auditLog
+	result.Async = nil
+	result.AsyncAuditOptionsType = options
+	// default values
+	if result.QueueSize <= 0 {
+		result.QueueSize = 10000
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = 100
+	}
+	if result.FlushInterval <= 0 {
+		result.FlushInterval = time.Second
+	}
+	if result.MaxRetries < 0 {
+		result.MaxRetries = 0
+	} else if result.MaxRetries == 0 {
+		result.MaxRetries = 3
+	}
+	if result.RetryBackoff <= 0 {
+		result.RetryBackoff = 100 * time.Millisecond
+	}
+	result.queue = make(chan Audit, result.QueueSize)
+	result.done = make(chan struct{})
+	go result.run()
+	return result
+}
+
+// Enqueue adds the audit-log entry to the queue, without blocking.
+// It returns false, and counts the entry as dropped, if the queue is full or the writer is closed.
+func (asyncLog *AsyncAuditLog) Enqueue(audit Audit) bool {
+	asyncLog.mutex.RLock()
+	defer asyncLog.mutex.RUnlock()
+	if asyncLog.closed {
+		atomic.AddInt64(&asyncLog.dropped, 1)
+		return false
+	}
+	select {
+	case asyncLog.queue <- audit:
+		return true
+	default:
+		atomic.AddInt64(&asyncLog.dropped, 1)
+		return false
+	}
+}
+
+// Dropped returns the number of audit-log entries dropped (queue full, writer closed or failed batch-inserts)
+func (asyncLog *AsyncAuditLog) Dropped() int64 {
+	return atomic.LoadInt64(&asyncLog.dropped)
+}
+
+// Pending returns the number of queued audit-log entries
+func (asyncLog *AsyncAuditLog) Pending() int {
+	return len(asyncLog.queue)
+}
+
+// Close stops accepting new entries, flushes the queued entries and waits for the writer to finish (e.g. on shutdown)
+func (asyncLog *AsyncAuditLog) Close() {
+	asyncLog.stopOnce.Do(func() {
+		asyncLog.mutex.Lock()
+		asyncLog.closed = true
+		close(asyncLog.queue)
+		asyncLog.mutex.Unlock()
+	})
+	<-asyncLog.done
+}
+
+// run collects the queued entries into batches, written when full, on the flush-interval and on close
+func (asyncLog *AsyncAuditLog) run() {
+	defer close(asyncLog.done)
+	ticker := time.NewTicker(asyncLog.FlushInterval)
+	defer ticker.Stop()
+	var batch []Audit
+	for {
+		select {
+		case audit, ok := <-asyncLog.queue:
+			if !ok {
+				asyncLog.writeBatch(batch)
+				return
+			}
+			batch = append(batch, audit)
+			if len(batch) >= asyncLog.BatchSize {
+				asyncLog.writeBatch(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			asyncLog.writeBatch(batch)
+			batch = nil
+		}
+	}
+}
+
+// writeBatch inserts the batch, with retries and exponential backoff
+func (asyncLog *AsyncAuditLog) writeBatch(batch []Audit) {
+	if len(batch) < 1 {
+		return
+	}
+	var err error
+	backoff := asyncLog.RetryBackoff
+	for attempt := 0; attempt <= asyncLog.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = asyncLog.createAudits(batch).Error; err == nil {
+			return
+		}
+	}
+	atomic.AddInt64(&asyncLog.dropped, int64(len(batch)))
+	if asyncLog.OnError != nil {
+		asyncLog.OnError(err, batch)
+	}
+}
+
+// writeAudit inserts the audit-log entry, or queues it for the asynchronous writer, if specified
+func (log LogParam) writeAudit(audit *Audit) *gorm.DB {
+	if log.Async == nil {
+		return log.createAudit(audit)
+	}
+	if !log.Async.Enqueue(*audit) {
+		return &gorm.DB{Error: errors.New("audit-log queue is full or closed, entry dropped")}
+	}
+	return &gorm.DB{RowsAffected: 1}
+}
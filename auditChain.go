@@ -0,0 +1,196 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - tamper-evident hash-chain
+
+package mcgorm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// AuditChainResultType for VerifyAuditChain method value (interface{}) response,
+// and to assert returned value
+type AuditChainResultType struct {
+	Ok           bool   `json:"ok"`
+	CheckedCount int    `json:"checkedCount"`
+	AnchorHash   string `json:"anchorHash"` // prevHash of the first (oldest) chained entry
+	BrokenId     string `json:"brokenId"`   // id of the first entry with a broken link or altered content
+	Message      string `json:"message"`
+}
+
+// hash-chain writes are serialised in-process, and by the audit-chain db-lock (per audit-table) across the processes
+var auditChainMutex sync.Mutex
+
+// ComputeAuditHash returns the sha256-hash of the audit-entry content and the previous entry hash
+func ComputeAuditHash(audit Audit, prevHash string) string {
+	content := map[string]interface{}{
+		"id":            audit.ID,
+		"tableName":     audit.TableName,
+		"logRecords":    audit.LogRecords,
+		"newLogRecords": audit.NewLogRecords,
+		"logDiffs":      audit.LogDiffs,
+		"logType":       audit.LogType,
+		"logBy":         audit.LogBy,
+		"logAt":         audit.LogAt.UTC().Format(time.RFC3339Nano),
+		"appId":         audit.AppId,
+		"recordIds":     audit.RecordIds,
+		"queryParams":   audit.QueryParams,
+		"clientIp":      audit.ClientIp,
+		"userAgent":     audit.UserAgent,
+		"requestId":     audit.RequestId,
+		"correlationId": audit.CorrelationId,
+		"prevHash":      prevHash,
+	}
+	// canonical json-value (sorted keys), as stored in / retrieved from the json-columns
+	jByte, _ := json.Marshal(content)
+	var canonicalValue interface{}
+	_ = json.Unmarshal(jByte, &canonicalValue)
+	jByte, _ = json.Marshal(canonicalValue)
+	hash := sha256.Sum256(jByte)
+	return hex.EncodeToString(hash[:])
+}
+
+// VerifyAuditEntries checks the hash-links and the content-hash of the ordered audit-entries, from the prevHash.
+// It returns the index of the first broken entry and the reason, or -1 if all entries are valid.
+func VerifyAuditEntries(audits []Audit, prevHash string) (int, string) {
+	for i, audit := range audits {
+		if audit.PrevHash != prevHash {
+			return i, fmt.Sprintf("broken link: prevHash %v does not match the previous entry hash %v", audit.PrevHash, prevHash)
+		}
+		if ComputeAuditHash(audit, audit.PrevHash) != audit.Hash {
+			return i, "altered content: entry hash does not match the entry content"
+		}
+		prevHash = audit.Hash
+	}
+	return -1, ""
+}
+
+// createAudit inserts the audit-log entry, computing the hash-chain values, if enabled
+func (log LogParam) createAudit(audit *Audit) *gorm.DB {
+	audits := []Audit{*audit}
+	result := log.createAudits(audits)
+	*audit = audits[0]
+	return result
+}
+
+// createAudits inserts the audit-log entries (in order), computing the hash-chain values, if enabled
+func (log LogParam) createAudits(audits []Audit) *gorm.DB {
+	// set the new (time-ordered) audit-ids
+	for i := range audits {
+		if audits[i].ID != "" {
+			continue
+		}
+		id, err := NewId(audits[i].IdType())
+		if err != nil {
+			return &gorm.DB{Error: err}
+		}
+		audits[i].ID = id
+	}
+	if !log.HashChain {
+		return log.AuditDb.Table(log.AuditTable).Create(&audits)
+	}
+	auditChainMutex.Lock()
+	defer auditChainMutex.Unlock()
+	var result *gorm.DB
+	_ = log.AuditDb.Transaction(func(tx *gorm.DB) error {
+		// read the chain head, holding the audit-chain lock, until the entries are inserted
+		lockName := log.AuditTable + "_chain_lock"
+		var lockRes *gorm.DB
+		switch tx.Dialector.Name() {
+		case "postgres":
+			lockRes = tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey(lockName))
+		case "mysql":
+			var locked int
+			if lockRes = tx.Raw("SELECT GET_LOCK(?, ?)", lockName, 60).Scan(&locked); lockRes.Error == nil && locked != 1 {
+				lockRes.Error = errors.New(fmt.Sprintf("audit-chain lock %v not acquired", lockName))
+			}
+			defer tx.Exec("SELECT RELEASE_LOCK(?)", lockName)
+		}
+		if lockRes != nil && lockRes.Error != nil {
+			result = lockRes
+			return lockRes.Error
+		}
+		var lastAudits []Audit
+		lastRes := tx.Table(log.AuditTable).Select("hash", "log_at").Where("hash <> ?", "").Order("log_at desc").Limit(1).Find(&lastAudits)
+		if lastRes.Error != nil {
+			result = lastRes
+			return lastRes.Error
+		}
+		prevHash := ""
+		var lastAt time.Time
+		if len(lastAudits) > 0 {
+			prevHash = lastAudits[0].Hash
+			lastAt = lastAudits[0].LogAt.UTC().Truncate(time.Millisecond)
+		}
+		for i := range audits {
+			// strictly increasing log_at (millisecond precision, as stored), for the chain order
+			logAt := audits[i].LogAt.UTC().Truncate(time.Millisecond)
+			if !logAt.After(lastAt) {
+				logAt = lastAt.Add(time.Millisecond)
+			}
+			audits[i].LogAt = logAt
+			lastAt = logAt
+			audits[i].PrevHash = prevHash
+			audits[i].Hash = ComputeAuditHash(audits[i], prevHash)
+			prevHash = audits[i].Hash
+		}
+		result = tx.Table(log.AuditTable).Create(&audits)
+		return result.Error
+	})
+	return result
+}
+
+// VerifyAuditChain method walks the hash-chained audit-log entries (log_at order), and reports the first broken link
+// or altered entry. The prevHash of the oldest entry is the chain anchor (e.g. after retention-purge).
+func (log LogParam) VerifyAuditChain() (mcresponse.ResponseMessage, error) {
+	batchSize := 1000
+	chainResult := AuditChainResultType{Ok: true}
+	prevHash := ""
+	for skip := 0; ; skip += batchSize {
+		var audits []Audit
+		result := log.AuditDb.Table(log.AuditTable).Where("hash <> ?", "").Order("log_at asc").Limit(batchSize).Offset(skip).Find(&audits)
+		if result.Error != nil {
+			errMsg := fmt.Sprintf("Audit-log read-error: %v", result.Error.Error())
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: errMsg,
+				Value:   nil,
+			}), errors.New(errMsg)
+		}
+		if len(audits) < 1 {
+			break
+		}
+		if skip == 0 {
+			prevHash = audits[0].PrevHash
+			chainResult.AnchorHash = prevHash
+		}
+		brokenIndex, message := VerifyAuditEntries(audits, prevHash)
+		if brokenIndex >= 0 {
+			chainResult.Ok = false
+			chainResult.CheckedCount += brokenIndex + 1
+			chainResult.BrokenId = audits[brokenIndex].ID
+			chainResult.Message = message
+			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Audit-log chain broken at entry %v: %v", chainResult.BrokenId, message),
+				Value:   chainResult,
+			}), nil
+		}
+		chainResult.CheckedCount += len(audits)
+		prevHash = audits[len(audits)-1].Hash
+		if len(audits) < batchSize {
+			break
+		}
+	}
+	chainResult.Message = "audit-log chain verified"
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Audit-log chain verified successfully",
+		Value:   chainResult,
+	}), nil
+}
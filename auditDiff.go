@@ -0,0 +1,79 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - field-level changes (diff) for update-log
+
+package mcgorm
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldDiffType describes the old and new value of an updated field
+type FieldDiffType struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// RecordDiffType describes the changed fields of an updated record
+type RecordDiffType struct {
+	RecordId string          `json:"recordId"`
+	Fields   []FieldDiffType `json:"fields"`
+}
+
+// ComputeRecordDiffs returns the field-level changes (unchanged fields are skipped) between the current/old records
+// and the update record(s). A single update record applies to all current records, otherwise the update records are
+// matched to the current records by id.
+func ComputeRecordDiffs(oldRecords interface{}, newRecords interface{}) []RecordDiffType {
+	var recordDiffs []RecordDiffType
+	oldRecs := toJsonMaps(oldRecords)
+	newRecs := toJsonMaps(newRecords)
+	if len(oldRecs) < 1 || len(newRecs) < 1 {
+		return recordDiffs
+	}
+	newRecsById := map[string]map[string]interface{}{}
+	for _, rec := range newRecs {
+		if id, ok := rec["id"].(string); ok && id != "" {
+			newRecsById[id] = rec
+		}
+	}
+	for _, oldRec := range oldRecs {
+		id, _ := oldRec["id"].(string)
+		newRec, ok := newRecsById[id]
+		if !ok {
+			if len(newRecs) != 1 {
+				continue
+			}
+			newRec = newRecs[0]
+		}
+		// compute changed fields, in field-name order
+		var fields []string
+		for field := range newRec {
+			if field != "id" {
+				fields = append(fields, field)
+			}
+		}
+		sort.Strings(fields)
+		var fieldDiffs []FieldDiffType
+		for _, field := range fields {
+			oldValue := oldRec[field]
+			newValue := newRec[field]
+			if reflect.DeepEqual(oldValue, newValue) {
+				continue
+			}
+			fieldDiffs = append(fieldDiffs, FieldDiffType{
+				Field:    field,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+		if len(fieldDiffs) > 0 {
+			recordDiffs = append(recordDiffs, RecordDiffType{
+				RecordId: id,
+				Fields:   fieldDiffs,
+			})
+		}
+	}
+	return recordDiffs
+}
@@ -17,6 +17,8 @@ import (
 type LogParam struct {
 	AuditDb    *gorm.DB
 	AuditTable string
+	HashChain  bool           // tamper-evident, hash-chained audit-log entries
+	Async      *AsyncAuditLog // queue the audit-log entries for the asynchronous, batched writer, if specified
 }
 
 // AuditLogOptionsType set the audit-log optional parameters
@@ -25,20 +27,43 @@ type AuditLogOptionsType struct {
 	TableName     string
 	LogRecords    interface{}
 	NewLogRecords interface{}
-	//QueryParams   interface{}
+	AppId         string
+	RecordIds     []string
+	LogDiffs      []RecordDiffType // field-level changes, for update-log
+	DiffOnly      bool             // store only the field-level changes (logDiffs), for update-log
+	QueryParams   interface{}
+	RequestMeta   RequestMetaType
 }
 
 // Audit describe the data-model for Audit log
 type Audit struct {
-	ID            string      `json:"id" gorm:"primaryKey;default:uuid_generate_v4()" mcorm:"id"`
-	TableName     string      `json:"tableName" mcorm:"table_name"`
-	LogRecords    interface{} `json:"logRecords" mcorm:"log_records"`
-	NewLogRecords interface{} `json:"newLogRecords" mcorm:"new_log_records"`
-	LogType       string      `json:"logType" mcorm:"log_type"`
-	LogBy         string      `json:"logBy" mcorm:"log_by"`
-	LogAt         time.Time   `json:"logAt" mcorm:"log_at"`
+	ID            string       `json:"id" gorm:"primaryKey" mcorm:"id"`
+	TableName     string       `json:"tableName" mcorm:"table_name"`
+	LogRecords    JsonDataType `json:"logRecords" mcorm:"log_records"`
+	NewLogRecords JsonDataType `json:"newLogRecords" mcorm:"new_log_records"`
+	LogDiffs      JsonDataType `json:"logDiffs" mcorm:"log_diffs"`
+	LogType       string       `json:"logType" mcorm:"log_type"`
+	LogBy         string       `json:"logBy" mcorm:"log_by"`
+	LogAt         time.Time    `json:"logAt" mcorm:"log_at"`
+	AppId         string       `json:"appId" mcorm:"app_id"`
+	RecordIds     string       `json:"recordIds" mcorm:"record_ids"` // comma-separated record-ids
+	QueryParams   JsonDataType `json:"queryParams" mcorm:"query_params"`
+	ClientIp      string       `json:"clientIp" mcorm:"client_ip"`
+	UserAgent     string       `json:"userAgent" mcorm:"user_agent"`
+	RequestId     string       `json:"requestId" mcorm:"request_id"`
+	CorrelationId string       `json:"correlationId" mcorm:"correlation_id"`
+	Hash          string       `json:"hash" mcorm:"hash"`
+	PrevHash      string       `json:"prevHash" mcorm:"prev_hash"`
 }
 
+// IdType method returns the time-ordered id-type of the audit-log entries
+func (Audit) IdType() string {
+	return UuidV7Id
+}
+
+// AuditIndexFields are the indexed audit-table fields/columns
+var AuditIndexFields = []string{"table_name", "log_type", "log_by", "log_at", "request_id", "correlation_id"}
+
 type AuditLogger interface {
 	AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error)
 }
@@ -103,8 +128,26 @@ func (log LogParam) String() string {
 		log.AuditTable)
 }
 
-// AuditLog method compose and insert new audit-log record
-func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+// SetupAuditTable method creates/migrates the audit-table and the indexes for table_name, log_type, log_by and log_at
+func (log LogParam) SetupAuditTable() error {
+	if err := log.AuditDb.Table(log.AuditTable).AutoMigrate(&Audit{}); err != nil {
+		return errors.New(fmt.Sprintf("error migrating audit-table %v: %v", log.AuditTable, err.Error()))
+	}
+	for _, field := range AuditIndexFields {
+		indexName := fmt.Sprintf("idx_%v_%v", log.AuditTable, field)
+		if log.AuditDb.Migrator().HasIndex(log.AuditTable, indexName) {
+			continue
+		}
+		indexScript := fmt.Sprintf("CREATE INDEX %v ON %v (%v)", indexName, log.AuditTable, field)
+		if err := log.AuditDb.Exec(indexScript).Error; err != nil {
+			return errors.New(fmt.Sprintf("error creating audit-table index %v: %v", indexName, err.Error()))
+		}
+	}
+	return nil
+}
+
+// composeAudit validates the audit-log params and composes the audit-log record, for the audit-log sinks
+func composeAudit(logType, userId string, options AuditLogOptionsType) (Audit, mcresponse.ResponseMessage, error) {
 	// variables
 	logType = strings.ToLower(logType)
 	logBy := userId
@@ -143,7 +186,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
@@ -152,7 +195,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 		// perform crud action
 		audit = Audit{
 			TableName:  tableName,
-			LogRecords: logRecords,
+			LogRecords: JsonDataType{Data: logRecords},
 			LogType:    logType,
 			LogBy:      logBy,
 			LogAt:      time.Now(),
@@ -174,14 +217,15 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 				errorMessage = "userId is required."
 			}
 		}
-		if logRecords == nil {
+		// updated and new record(s) information are not required, if the field-level changes (logDiffs) are specified
+		if logRecords == nil && options.LogDiffs == nil {
 			if errorMessage != "" {
 				errorMessage = errorMessage + " | Updated record(s) information is required."
 			} else {
 				errorMessage = "Updated record(s) information is required."
 			}
 		}
-		if newLogRecords == nil {
+		if newLogRecords == nil && options.LogDiffs == nil {
 			if errorMessage != "" {
 				errorMessage = errorMessage + " | New/Update record(s) information is required."
 			} else {
@@ -189,17 +233,23 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
 				}), errors.New(errorMessage)
 		}
+		// store only the field-level changes, if specified
+		if options.DiffOnly && options.LogDiffs != nil {
+			logRecords = nil
+			newLogRecords = nil
+		}
 		// perform crud action
 		audit = Audit{
 			TableName:     tableName,
-			LogRecords:    logRecords,
-			NewLogRecords: newLogRecords,
+			LogRecords:    JsonDataType{Data: logRecords},
+			NewLogRecords: JsonDataType{Data: newLogRecords},
+			LogDiffs:      JsonDataType{Data: options.LogDiffs},
 			LogType:       logType,
 			LogBy:         logBy,
 			LogAt:         time.Now(),
@@ -228,7 +278,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
@@ -237,7 +287,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 		// perform crud action
 		audit = Audit{
 			TableName:  tableName,
-			LogRecords: logRecords,
+			LogRecords: JsonDataType{Data: logRecords},
 			LogType:    logType,
 			LogBy:      logBy,
 			LogAt:      time.Now(),
@@ -266,7 +316,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
@@ -275,7 +325,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 		// perform crud action
 		audit = Audit{
 			TableName:  tableName,
-			LogRecords: logRecords,
+			LogRecords: JsonDataType{Data: logRecords},
 			LogType:    logType,
 			LogBy:      logBy,
 			LogAt:      time.Now(),
@@ -304,7 +354,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
@@ -313,7 +363,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 		// perform crud action
 		audit = Audit{
 			TableName:  tableName,
-			LogRecords: logRecords,
+			LogRecords: JsonDataType{Data: logRecords},
 			LogType:    logType,
 			LogBy:      logBy,
 			LogAt:      time.Now(),
@@ -342,7 +392,7 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 			}
 		}
 		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+			return audit, mcresponse.GetResMessage("paramsError",
 				mcresponse.ResponseMessageOptions{
 					Message: errorMessage,
 					Value:   nil,
@@ -351,76 +401,41 @@ func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType
 		// perform crud action
 		audit = Audit{
 			TableName:  tableName,
-			LogRecords: logRecords,
+			LogRecords: JsonDataType{Data: logRecords},
 			LogType:    logType,
 			LogBy:      logBy,
 			LogAt:      time.Now(),
 		}
 	default:
-		return mcresponse.GetResMessage("logError",
+		return audit, mcresponse.GetResMessage("logError",
 			mcresponse.ResponseMessageOptions{
 				Message: "Unknown log type and/or incomplete log information",
 				Value:   nil,
 			}), errors.New("unknown log type and/or incomplete log information")
 	}
 
-	// perform audit-log-create task
-	result := log.AuditDb.Create(&audit)
-
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
+	// app/tenant-scope and record-ids
+	audit.AppId = options.AppId
+	audit.RecordIds = strings.Join(options.RecordIds, ",")
+	// request information
+	audit.QueryParams = JsonDataType{Data: options.QueryParams}
+	audit.ClientIp = options.RequestMeta.ClientIp
+	audit.UserAgent = options.RequestMeta.UserAgent
+	audit.RequestId = options.RequestMeta.RequestId
+	audit.CorrelationId = options.RequestMeta.CorrelationId
 
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+	return audit, mcresponse.ResponseMessage{}, nil
 }
 
-func (log LogParam) CreateLog(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Created record(s) information is required."
-		} else {
-			errorMessage = "Created record(s) information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
-	// perform crud action
-	audit := Audit{
-		TableName:  tableName,
-		LogRecords: logRecords,
-		LogType:    CreateLog,
-		LogBy:      userId,
-		LogAt:      time.Now(),
+// AuditLog method compose and insert new audit-log record
+func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	audit, errRes, err := composeAudit(logType, userId, options)
+	if err != nil {
+		return errRes, err
 	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
+
+	// perform audit-log-create task
+	result := log.writeAudit(&audit)
 
 	// Handle error
 	if result.Error != nil {
@@ -439,285 +454,66 @@ func (log LogParam) CreateLog(tableName string, logRecords interface{}, userId s
 		}), nil
 }
 
-func (log LogParam) UpdateLog(tableName string, logRecords interface{}, newLogRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Updated record(s) information is required."
-		} else {
-			errorMessage = "Updated record(s) information is required."
-		}
-	}
-	if newLogRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | New/Update record(s) information is required."
-		} else {
-			errorMessage = "New/Update record(s) information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
+// CreateLog method inserts the create-log record of the created record(s), with the (optional) request information
+func (log LogParam) CreateLog(tableName string, logRecords interface{}, userId string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(CreateLog, userId, AuditLogOptionsType{
+		TableName:   tableName,
+		LogRecords:  logRecords,
+		RequestMeta: requestMetaOf(requestMeta),
+	})
+}
 
-	audit := Audit{
+// UpdateLog method inserts the update-log record of the updated (current and new) record(s), with the (optional)
+// request information
+func (log LogParam) UpdateLog(tableName string, logRecords interface{}, newLogRecords interface{}, userId string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(UpdateLog, userId, AuditLogOptionsType{
 		TableName:     tableName,
 		LogRecords:    logRecords,
 		NewLogRecords: newLogRecords,
-		LogType:       CreateLog,
-		LogBy:         userId,
-		LogAt:         time.Now(),
-	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
-
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
-
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+		RequestMeta:   requestMetaOf(requestMeta),
+	})
 }
 
-func (log LogParam) ReadLog(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Read/Get Params/Keywords information is required."
-		} else {
-			errorMessage = "Read/Get Params/Keywords information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
-	// perform crud action
-	audit := Audit{
-		TableName:  tableName,
-		LogRecords: logRecords,
-		LogType:    CreateLog,
-		LogBy:      userId,
-		LogAt:      time.Now(),
-	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
-
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
-
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+// ReadLog method inserts the read-log record of the read/get params/keywords, with the (optional) request information
+func (log LogParam) ReadLog(tableName string, logRecords interface{}, userId string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(ReadLog, userId, AuditLogOptionsType{
+		TableName:   tableName,
+		LogRecords:  logRecords,
+		RequestMeta: requestMetaOf(requestMeta),
+	})
 }
 
-func (log LogParam) DeleteLog(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Deleted record(s) information is required."
-		} else {
-			errorMessage = "Deleted record(s) information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
-	// perform crud action
-	audit := Audit{
-		TableName:  tableName,
-		LogRecords: logRecords,
-		LogType:    CreateLog,
-		LogBy:      userId,
-		LogAt:      time.Now(),
-	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
-
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
-
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+// DeleteLog method inserts the delete-log record of the deleted record(s), with the (optional) request information
+func (log LogParam) DeleteLog(tableName string, logRecords interface{}, userId string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(DeleteLog, userId, AuditLogOptionsType{
+		TableName:   tableName,
+		LogRecords:  logRecords,
+		RequestMeta: requestMetaOf(requestMeta),
+	})
 }
 
-func (log LogParam) LoginLog(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Login record(s) information is required."
-		} else {
-			errorMessage = "Login record(s) information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
-	// perform crud action
-	audit := Audit{
-		TableName:  tableName,
-		LogRecords: logRecords,
-		LogType:    CreateLog,
-		LogBy:      userId,
-		LogAt:      time.Now(),
-	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
-
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
-
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+// LoginLog method inserts the login-log record of the login information, with the (optional) request information
+func (log LogParam) LoginLog(logRecords interface{}, userId string, tableName string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(LoginLog, userId, AuditLogOptionsType{
+		TableName:   tableName,
+		LogRecords:  logRecords,
+		RequestMeta: requestMetaOf(requestMeta),
+	})
 }
 
-func (log LogParam) LogoutLog(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
-	// validate params
-	var errorMessage = ""
-	if tableName == "" {
-		errorMessage = "Table or Collection name is required."
-	}
-	if userId == "" {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | userId is required."
-		} else {
-			errorMessage = "userId is required."
-		}
-	}
-	if logRecords == nil {
-		if errorMessage != "" {
-			errorMessage = errorMessage + " | Logout record(s) information is required."
-		} else {
-			errorMessage = "Logout record(s) information is required."
-		}
-	}
-	if errorMessage != "" {
-		return mcresponse.GetResMessage("paramsError",
-			mcresponse.ResponseMessageOptions{
-				Message: errorMessage,
-				Value:   nil,
-			}), errors.New(errorMessage)
-	}
-	// perform crud action
-	audit := Audit{
-		TableName:  tableName,
-		LogRecords: logRecords,
-		LogType:    CreateLog,
-		LogBy:      userId,
-		LogAt:      time.Now(),
-	}
-	// perform audit-log-insert task
-	result := log.AuditDb.Create(&audit)
+// LogoutLog method inserts the logout-log record of the logout information, with the (optional) request information
+func (log LogParam) LogoutLog(logRecords interface{}, userId string, tableName string, requestMeta ...RequestMetaType) (mcresponse.ResponseMessage, error) {
+	return log.AuditLog(LogoutLog, userId, AuditLogOptionsType{
+		TableName:   tableName,
+		LogRecords:  logRecords,
+		RequestMeta: requestMetaOf(requestMeta),
+	})
+}
 
-	// Handle error
-	if result.Error != nil {
-		errMsg := fmt.Sprintf("Log-record create-error: %v", result.Error.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
+// requestMetaOf returns the (optional) request information of the audit-log methods
+func requestMetaOf(requestMeta []RequestMetaType) RequestMetaType {
+	if len(requestMeta) > 0 {
+		return requestMeta[0]
 	}
-
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   result.RowsAffected,
-		}), nil
+	return RequestMetaType{}
 }
@@ -0,0 +1,188 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - query/search methods
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuditQueryParamsType set the audit-log query/search parameters
+type AuditQueryParamsType struct {
+	TableName     string
+	RecordId      string
+	LogBy         string
+	LogType       string
+	LogTypes      []string // log_type IN LogTypes, if specified, e.g. the change log-types
+	AppId         string
+	RequestId     string
+	CorrelationId string
+	From          time.Time // log_at >= From, if specified
+	To            time.Time // log_at <= To, if specified
+	Skip          int
+	Limit         int
+	SortParams    SortParamType // e.g. {"logAt": -1}, default log_at desc
+}
+
+// AuditQueryResultType for GetAuditLogs method value (interface{}) response,
+// and to assert returned value
+type AuditQueryResultType struct {
+	Records   []Audit     `json:"value"`
+	Stats     GetStatType `json:"stats"`
+	Truncated bool        `json:"truncated"` // more records (Stats.TotalRecordsCount) than skipped and returned, e.g. at the limit
+}
+
+// AuditSortFields are the permitted audit-log sort fields/columns
+var AuditSortFields = []string{"table_name", "log_type", "log_by", "log_at", "app_id"}
+
+// AuditQueryMaxLimit is the maximum (and default) number of the audit-log records returned by a query
+const AuditQueryMaxLimit = 10000
+
+// ChangeLogTypes are the audit log-types of the record changes
+var ChangeLogTypes = []string{CreateLog, UpdateLog, DeleteLog, RemoveLog}
+
+// escapeLike escapes the LIKE wildcards (%, _) and the escape character (!) of the pattern value, for ESCAPE '!'
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
+// auditQuery composes the where-conditions, from the audit-query-params
+func (log LogParam) auditQuery(params AuditQueryParamsType) *gorm.DB {
+	query := log.AuditDb.Table(log.AuditTable)
+	if params.TableName != "" {
+		query = query.Where("table_name = ?", params.TableName)
+	}
+	if params.RecordId != "" {
+		// record_ids: comma-separated record-ids
+		id := escapeLike(params.RecordId)
+		query = query.Where("(record_ids = ? OR record_ids LIKE ? ESCAPE '!' OR record_ids LIKE ? ESCAPE '!' OR record_ids LIKE ? ESCAPE '!')",
+			params.RecordId, id+",%", "%,"+id, "%,"+id+",%")
+	}
+	if params.LogBy != "" {
+		query = query.Where("log_by = ?", params.LogBy)
+	}
+	if params.LogType != "" {
+		query = query.Where("log_type = ?", params.LogType)
+	}
+	if len(params.LogTypes) > 0 {
+		query = query.Where("log_type IN ?", params.LogTypes)
+	}
+	if params.AppId != "" {
+		query = query.Where("app_id = ?", params.AppId)
+	}
+	if params.RequestId != "" {
+		query = query.Where("request_id = ?", params.RequestId)
+	}
+	if params.CorrelationId != "" {
+		query = query.Where("correlation_id = ?", params.CorrelationId)
+	}
+	if !params.From.IsZero() {
+		query = query.Where("log_at >= ?", params.From)
+	}
+	if !params.To.IsZero() {
+		query = query.Where("log_at <= ?", params.To)
+	}
+	return query
+}
+
+// GetAuditLogs method returns the audit-log records, by the query/search params, with paging and sorting
+func (log LogParam) GetAuditLogs(params AuditQueryParamsType) (mcresponse.ResponseMessage, error) {
+	// default values
+	if params.Skip < 0 {
+		params.Skip = 0
+	}
+	if params.Limit <= 0 || params.Limit > AuditQueryMaxLimit {
+		params.Limit = AuditQueryMaxLimit
+	}
+	// compute order-by, by sorted sort-fields
+	var sortKeys []string
+	for key := range params.SortParams {
+		sortKeys = append(sortKeys, key)
+	}
+	sort.Strings(sortKeys)
+	orderBy := ""
+	for _, key := range sortKeys {
+		field := govalidator.CamelCaseToUnderscore(key)
+		if !ArrayStringContains(AuditSortFields, field) {
+			errMsg := fmt.Sprintf("Sort field %v is not a valid audit-log field", key)
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: errMsg,
+				Value:   nil,
+			}), errors.New(errMsg)
+		}
+		if orderBy != "" {
+			orderBy += ", "
+		}
+		if params.SortParams[key] < 0 {
+			orderBy += field + " desc"
+		} else {
+			orderBy += field + " asc"
+		}
+	}
+	if orderBy == "" {
+		orderBy = "log_at desc"
+	}
+
+	var totalRecordsCount int64
+	if err := log.auditQuery(params).Count(&totalRecordsCount).Error; err != nil {
+		errMsg := fmt.Sprintf("Audit-log read-error: %v", err.Error())
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	var audits []Audit
+	result := log.auditQuery(params).Order(orderBy).Limit(params.Limit).Offset(params.Skip).Find(&audits)
+	if result.Error != nil {
+		errMsg := fmt.Sprintf("Audit-log read-error: %v", result.Error.Error())
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	message := "Task completed successfully"
+	truncated := params.Skip+len(audits) < int(totalRecordsCount)
+	if truncated {
+		message = fmt.Sprintf("Task completed successfully: %v of %v records returned, at the limit %v", len(audits), totalRecordsCount, params.Limit)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: message,
+		Value: AuditQueryResultType{
+			Records: audits,
+			Stats: GetStatType{
+				Skip:              params.Skip,
+				Limit:             params.Limit,
+				RecordsCount:      len(audits),
+				TotalRecordsCount: int(totalRecordsCount),
+			},
+			Truncated: truncated,
+		},
+	}), nil
+}
+
+// GetRecordHistory method returns the change (create, update, delete, remove) audit-log records for the table-record (id),
+// in change order (log_at asc), up to AuditQueryMaxLimit records (Truncated, if more)
+func (log LogParam) GetRecordHistory(tableName string, recordId string, appId string) (mcresponse.ResponseMessage, error) {
+	if tableName == "" || recordId == "" {
+		errMsg := "tableName and recordId are required"
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	return log.GetAuditLogs(AuditQueryParamsType{
+		TableName:  tableName,
+		RecordId:   recordId,
+		LogTypes:   ChangeLogTypes,
+		AppId:      appId,
+		SortParams: SortParamType{"logAt": 1},
+	})
+}
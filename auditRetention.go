@@ -0,0 +1,282 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - retention, purge, archive (gzipped NDJSON) and restore
+
+package mcgorm
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AuditRetentionPolicyType set the retention duration by log-type, e.g. {ReadLog: 30 * 24 * time.Hour}
+type AuditRetentionPolicyType map[string]time.Duration
+
+// AuditArchiveParamsType set the audit-log archive parameters
+type AuditArchiveParamsType struct {
+	ArchiveDir string    // directory for the archive file, default: current directory
+	Cutoff     time.Time // archive the entries with log_at < Cutoff
+	LogTypes   []string  // archive only the specified log-types, default: all
+	BatchSize  int       // read/delete batch size, default: 1000
+}
+
+// AuditArchiveResultType for ArchiveAudits method value (interface{}) response,
+// and to assert returned value
+type AuditArchiveResultType struct {
+	FilePath      string `json:"filePath"`
+	ArchivedCount int    `json:"archivedCount"`
+	DeletedCount  int64  `json:"deletedCount"`
+}
+
+// AuditRestoreResultType for RestoreAudits method value (interface{}) response,
+// and to assert returned value
+type AuditRestoreResultType struct {
+	ReadCount     int   `json:"readCount"`
+	RestoredCount int64 `json:"restoredCount"` // excludes the entries (ids) already in the audit-table
+}
+
+// PurgeAudits method deletes the audit-log entries older than the retention duration of their log-type.
+// The log-types without a retention duration are kept. For the hash-chained audit-log, the longest retention
+// duration applies to all log-types, to keep the remaining chain contiguous.
+func (log LogParam) PurgeAudits(policy AuditRetentionPolicyType) (mcresponse.ResponseMessage, error) {
+	if len(policy) < 1 {
+		errMsg := "retention policy is required"
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	now := time.Now()
+	deletedCounts := map[string]int64{}
+	if log.HashChain {
+		var maxRetention time.Duration
+		for _, retention := range policy {
+			if retention > maxRetention {
+				maxRetention = retention
+			}
+		}
+		result := log.AuditDb.Table(log.AuditTable).Where("log_at < ?", now.Add(-maxRetention)).Delete(&Audit{})
+		if result.Error != nil {
+			errMsg := fmt.Sprintf("Audit-log purge-error: %v", result.Error.Error())
+			return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+				Message: errMsg,
+				Value:   nil,
+			}), errors.New(errMsg)
+		}
+		deletedCounts["all"] = result.RowsAffected
+	} else {
+		for logType, retention := range policy {
+			result := log.AuditDb.Table(log.AuditTable).Where("log_type = ? AND log_at < ?", logType, now.Add(-retention)).Delete(&Audit{})
+			if result.Error != nil {
+				errMsg := fmt.Sprintf("Audit-log purge-error [%v]: %v", logType, result.Error.Error())
+				return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+					Message: errMsg,
+					Value:   deletedCounts,
+				}), errors.New(errMsg)
+			}
+			deletedCounts[logType] = result.RowsAffected
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Audit-log purge completed successfully",
+		Value:   deletedCounts,
+	}), nil
+}
+
+// WriteAuditArchive writes the audit-log entries to w, as NDJSON (one json-entry per line)
+func WriteAuditArchive(w io.Writer, audits []Audit) error {
+	encoder := json.NewEncoder(w)
+	for _, audit := range audits {
+		if err := encoder.Encode(audit); err != nil {
+			return errors.New(fmt.Sprintf("error encoding audit-log entry %v: %v", audit.ID, err.Error()))
+		}
+	}
+	return nil
+}
+
+// ReadAuditArchive reads the NDJSON audit-log entries from r, in batches, calling onBatch for each batch
+func ReadAuditArchive(r io.Reader, batchSize int, onBatch func(audits []Audit) error) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	readCount := 0
+	var batch []Audit
+	for {
+		var audit Audit
+		err := decoder.Decode(&audit)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return readCount, errors.New(fmt.Sprintf("error decoding audit-log entry %v: %v", readCount+1, err.Error()))
+		}
+		readCount++
+		batch = append(batch, audit)
+		if len(batch) >= batchSize {
+			if err = onBatch(batch); err != nil {
+				return readCount, err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		if err := onBatch(batch); err != nil {
+			return readCount, err
+		}
+	}
+	return readCount, nil
+}
+
+// archiveQuery composes the where-conditions, from the archive-params
+func (log LogParam) archiveQuery(params AuditArchiveParamsType) *gorm.DB {
+	query := log.AuditDb.Table(log.AuditTable).Where("log_at < ?", params.Cutoff)
+	if len(params.LogTypes) > 0 {
+		query = query.Where("log_type IN ?", params.LogTypes)
+	}
+	return query
+}
+
+// ArchiveAudits method exports the audit-log entries older than the cutoff to a gzipped NDJSON file
+// (<archiveDir>/<auditTable>_<cutoff>.ndjson.gz), and deletes each exported batch, after it is written to the file.
+// The archive file is kept on error, if any archived entries were deleted.
+// For the hash-chained audit-log, archive all log-types, to keep the remaining chain contiguous.
+func (log LogParam) ArchiveAudits(params AuditArchiveParamsType) (mcresponse.ResponseMessage, error) {
+	if params.Cutoff.IsZero() {
+		errMsg := "archive cutoff time is required"
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	if params.BatchSize <= 0 {
+		params.BatchSize = 1000
+	}
+	archiveResult := AuditArchiveResultType{}
+	archiveResult.FilePath = filepath.Join(params.ArchiveDir, fmt.Sprintf("%v_%v.ndjson.gz", log.AuditTable,
+		params.Cutoff.UTC().Format("20060102T150405Z")))
+	file, err := os.OpenFile(archiveResult.FilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		errMsg := fmt.Sprintf("Audit-log archive-file error: %v", err.Error())
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	// export the entries in log order, and delete each batch, once it is written (flushed and synced) to the file
+	errCode := "readError"
+	gzWriter := gzip.NewWriter(file)
+	for {
+		var audits []Audit
+		result := log.archiveQuery(params).Order("log_at asc, id asc").Limit(params.BatchSize).Find(&audits)
+		if result.Error != nil {
+			err = errors.New(fmt.Sprintf("Audit-log read-error: %v", result.Error.Error()))
+			break
+		}
+		if len(audits) < 1 {
+			break
+		}
+		if err = WriteAuditArchive(gzWriter, audits); err == nil {
+			if err = gzWriter.Flush(); err == nil {
+				err = file.Sync()
+			}
+		}
+		if err != nil {
+			break
+		}
+		archiveResult.ArchivedCount += len(audits)
+		var batchIds []string
+		for _, audit := range audits {
+			batchIds = append(batchIds, audit.ID)
+		}
+		result = log.AuditDb.Table(log.AuditTable).Where("id IN ?", batchIds).Delete(&Audit{})
+		if result.Error == nil && result.RowsAffected < 1 {
+			result.Error = errors.New("no archived entries deleted")
+		}
+		if result.Error != nil {
+			errCode = "removeError"
+			err = errors.New(fmt.Sprintf("delete-error: %v", result.Error.Error()))
+			break
+		}
+		archiveResult.DeletedCount += result.RowsAffected
+		if len(audits) < params.BatchSize {
+			break
+		}
+	}
+	// complete the archive file, also for the archived and deleted entries of the failed archive
+	if closeErr := gzWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if syncErr := file.Sync(); err == nil {
+		err = syncErr
+	}
+	_ = file.Close()
+	if err != nil {
+		if archiveResult.DeletedCount < 1 {
+			_ = os.Remove(archiveResult.FilePath)
+			archiveResult.FilePath = ""
+		}
+		errMsg := fmt.Sprintf("Audit-log archive error (%v entries archived, %v deleted): %v", archiveResult.ArchivedCount,
+			archiveResult.DeletedCount, err.Error())
+		return mcresponse.GetResMessage(errCode, mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   archiveResult,
+		}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Audit-log archive completed successfully",
+		Value:   archiveResult,
+	}), nil
+}
+
+// RestoreAudits method imports the audit-log entries from the gzipped NDJSON archive file,
+// skipping the entries (ids) already in the audit-table. The stored hash-chain values are kept as archived.
+func (log LogParam) RestoreAudits(filePath string) (mcresponse.ResponseMessage, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		errMsg := fmt.Sprintf("Audit-log archive-file error: %v", err.Error())
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	defer file.Close()
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		errMsg := fmt.Sprintf("Audit-log archive-file error: %v", err.Error())
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	defer gzReader.Close()
+	restoreResult := AuditRestoreResultType{}
+	restoreResult.ReadCount, err = ReadAuditArchive(gzReader, 1000, func(audits []Audit) error {
+		result := log.AuditDb.Table(log.AuditTable).Clauses(clause.OnConflict{DoNothing: true}).Create(&audits)
+		if result.Error != nil {
+			return errors.New(fmt.Sprintf("Audit-log restore-error: %v", result.Error.Error()))
+		}
+		restoreResult.RestoredCount += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   restoreResult,
+		}), err
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Audit-log restore completed successfully",
+		Value:   restoreResult,
+	}), nil
+}
@@ -0,0 +1,254 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect Audit Log - file (json-lines), syslog (RFC5424) and fan-out sinks
+
+package mcgorm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FileAuditSink writes the audit-log entries as json-lines to the file, rotated by size
+type FileAuditSink struct {
+	FilePath   string
+	MaxSize    int64 // rotation size in bytes, default: 100MB
+	MaxBackups int   // number of rotated files kept (<filePath>.1 ... <filePath>.n), default: 5
+	file       *os.File
+	size       int64
+	mutex      sync.Mutex
+}
+
+// SyslogAuditSink writes the audit-log entries as RFC5424 syslog messages, e.g. to a net.Conn (udp/tcp) or file
+type SyslogAuditSink struct {
+	Writer   io.Writer
+	Hostname string // default: os.Hostname
+	AppName  string // default: mcgorm
+	Facility int    // syslog facility, default: 13 (log audit)
+	mutex    sync.Mutex
+}
+
+// FanOutAuditSink writes the audit-log entries to all the sinks
+type FanOutAuditSink struct {
+	Sinks []AuditLogger
+}
+
+// syslog severity: informational
+const syslogSeverity = 6
+
+// NewFileAuditSink constructor returns a new json-lines file audit-log sink, appending to the filePath
+func NewFileAuditSink(filePath string, maxSize int64, maxBackups int) (*FileAuditSink, error) {
+	result := &FileAuditSink{}
+	result.FilePath = filePath
+	result.MaxSize = maxSize
+	result.MaxBackups = maxBackups
+	// default values
+	if result.MaxSize <= 0 {
+		result.MaxSize = 100 * 1024 * 1024
+	}
+	if result.MaxBackups <= 0 {
+		result.MaxBackups = 5
+	}
+	if err := result.openFile(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// openFile opens/creates the sink file, for append
+func (sink *FileAuditSink) openFile() error {
+	file, err := os.OpenFile(sink.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return errors.New(fmt.Sprintf("error opening audit-log file %v: %v", sink.FilePath, err.Error()))
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.New(fmt.Sprintf("error opening audit-log file %v: %v", sink.FilePath, err.Error()))
+	}
+	sink.file = file
+	sink.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backup files (<filePath>.n-1 => <filePath>.n) and starts a new sink file
+func (sink *FileAuditSink) rotate() error {
+	if err := sink.file.Close(); err != nil {
+		return errors.New(fmt.Sprintf("error closing audit-log file %v: %v", sink.FilePath, err.Error()))
+	}
+	_ = os.Remove(fmt.Sprintf("%v.%v", sink.FilePath, sink.MaxBackups))
+	for i := sink.MaxBackups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%v.%v", sink.FilePath, i), fmt.Sprintf("%v.%v", sink.FilePath, i+1))
+	}
+	if err := os.Rename(sink.FilePath, sink.FilePath+".1"); err != nil {
+		return errors.New(fmt.Sprintf("error rotating audit-log file %v: %v", sink.FilePath, err.Error()))
+	}
+	return sink.openFile()
+}
+
+// composeSinkAudit composes the audit-log entry of the file/syslog sinks, with the new (time-ordered) entry-id
+func composeSinkAudit(logType, userId string, options AuditLogOptionsType) (Audit, mcresponse.ResponseMessage, error) {
+	audit, errRes, err := composeAudit(logType, userId, options)
+	if err != nil {
+		return audit, errRes, err
+	}
+	id, err := NewId(audit.IdType())
+	if err != nil {
+		errMsg := fmt.Sprintf("Log-record id-error: %v", err.Error())
+		return audit, mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	audit.ID = id
+	return audit, mcresponse.ResponseMessage{}, nil
+}
+
+// AuditLog method compose and write the audit-log entry, as a json-line
+func (sink *FileAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	audit, errRes, err := composeSinkAudit(logType, userId, options)
+	if err != nil {
+		return errRes, err
+	}
+	jByte, err := json.Marshal(audit)
+	if err != nil {
+		errMsg := fmt.Sprintf("Log-record encode-error: %v", err.Error())
+		return mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	jByte = append(jByte, '\n')
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.size > 0 && sink.size+int64(len(jByte)) > sink.MaxSize {
+		err = sink.rotate()
+	}
+	if err == nil {
+		var n int
+		n, err = sink.file.Write(jByte)
+		sink.size += int64(n)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("Log-record write-error: %v", err.Error())
+		return mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "successful audit-log action",
+		Value:   int64(1),
+	}), nil
+}
+
+// Close closes the sink file
+func (sink *FileAuditSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return sink.file.Close()
+}
+
+// FormatSyslogMessage returns the RFC5424 syslog message for the audit-log entry, with the json-entry as message
+func FormatSyslogMessage(audit Audit, facility int, hostname string, appName string) (string, error) {
+	jByte, err := json.Marshal(audit)
+	if err != nil {
+		return "", err
+	}
+	// header-fields: no spaces, "-" for nil-value
+	headerValue := func(val string) string {
+		val = strings.ReplaceAll(strings.TrimSpace(val), " ", "_")
+		if val == "" {
+			return "-"
+		}
+		return val
+	}
+	return fmt.Sprintf("<%v>1 %v %v %v %v %v - %v\n",
+		facility*8+syslogSeverity,
+		audit.LogAt.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		headerValue(hostname),
+		headerValue(appName),
+		os.Getpid(),
+		headerValue(audit.LogType),
+		string(jByte),
+	), nil
+}
+
+// AuditLog method compose and write the audit-log entry, as a syslog message
+func (sink *SyslogAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	audit, errRes, err := composeSinkAudit(logType, userId, options)
+	if err != nil {
+		return errRes, err
+	}
+	hostname := sink.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	appName := sink.AppName
+	if appName == "" {
+		appName = "mcgorm"
+	}
+	facility := sink.Facility
+	if facility <= 0 {
+		facility = 13
+	}
+	message, err := FormatSyslogMessage(audit, facility, hostname, appName)
+	if err == nil {
+		sink.mutex.Lock()
+		_, err = io.WriteString(sink.Writer, message)
+		sink.mutex.Unlock()
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("Log-record write-error: %v", err.Error())
+		return mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "successful audit-log action",
+		Value:   int64(1),
+	}), nil
+}
+
+// AuditLog method writes the audit-log entry to all the sinks, and reports the failed sinks
+func (sink FanOutAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	var errMessages []string
+	var lastErrRes mcresponse.ResponseMessage
+	for i, auditSink := range sink.Sinks {
+		res, err := auditSink.AuditLog(logType, userId, options)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("sink[%v]: %v", i, err.Error()))
+			lastErrRes = res
+		}
+	}
+	if len(errMessages) > 0 {
+		errMsg := strings.Join(errMessages, " | ")
+		// all sinks failed with the same params-error
+		if lastErrRes.Code == "paramsError" && len(errMessages) == len(sink.Sinks) {
+			return lastErrRes, errors.New(errMsg)
+		}
+		return mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: errMsg,
+			Value:   nil,
+		}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "successful audit-log action",
+		Value:   int64(len(sink.Sinks)),
+	}), nil
+}
+
+// ensure the sinks implement the AuditLogger interface
+var (
+	_ AuditLogger = LogParam{}
+	_ AuditLogger = &FileAuditSink{}
+	_ AuditLogger = &SyslogAuditSink{}
+	_ AuditLogger = FanOutAuditSink{}
+)
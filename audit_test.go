@@ -0,0 +1,295 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log helpers test cases
+
+package mcgorm
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/abbeymart/mctest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestComputeRecordDiffs(t *testing.T) {
+	oldRecords := GetResultType{
+		Records: []interface{}{
+			map[string]interface{}{"id": "g1", "name": "services", "desc": "group one"},
+			map[string]interface{}{"id": "g2", "name": "products", "desc": "group two"},
+		},
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the changed fields only, for a single update record:",
+		TestFunc: func() {
+			res := ComputeRecordDiffs(oldRecords, map[string]interface{}{"name": "services", "desc": "updated"})
+			mctest.AssertEquals(t, len(res), 2, "record-diffs count should be: 2")
+			mctest.AssertEquals(t, res[0].RecordId, "g1", "first record-diff id should be: g1")
+			mctest.AssertEquals(t, len(res[0].Fields), 1, "changed fields count should be: 1")
+			mctest.AssertEquals(t, res[0].Fields[0].Field, "desc", "changed field should be: desc")
+			mctest.AssertEquals(t, res[0].Fields[0].OldValue, "group one", "old value should be: group one")
+			mctest.AssertEquals(t, res[0].Fields[0].NewValue, "updated", "new value should be: updated")
+			mctest.AssertEquals(t, len(res[1].Fields), 2, "changed fields count should be: 2")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should match the update records by id, and skip unchanged records:",
+		TestFunc: func() {
+			res := ComputeRecordDiffs(oldRecords, []interface{}{
+				map[string]interface{}{"id": "g1", "name": "services", "desc": "group one"},
+				map[string]interface{}{"id": "g2", "name": "goods", "desc": "group two"},
+			})
+			mctest.AssertEquals(t, len(res), 1, "record-diffs count should be: 1")
+			mctest.AssertEquals(t, res[0].RecordId, "g2", "record-diff id should be: g2")
+			mctest.AssertEquals(t, res[0].Fields[0].Field, "name", "changed field should be: name")
+			mctest.AssertEquals(t, res[0].Fields[0].NewValue, "goods", "new value should be: goods")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestAuditHashChain(t *testing.T) {
+	logAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	audit1 := Audit{ID: "a1", TableName: GroupTable, LogRecords: JsonDataType{Data: Recs}, LogType: CreateLog, LogBy: UserId, LogAt: logAt}
+	audit1.Hash = ComputeAuditHash(audit1, "")
+	audit2 := Audit{ID: "a2", TableName: GroupTable, LogRecords: JsonDataType{Data: Recs}, NewLogRecords: JsonDataType{Data: NewRecs}, LogType: UpdateLog, LogBy: UserId, LogAt: logAt.Add(time.Millisecond), PrevHash: audit1.Hash}
+	audit2.Hash = ComputeAuditHash(audit2, audit1.Hash)
+	audit3 := Audit{ID: "a3", TableName: GroupTable, LogRecords: JsonDataType{Data: NewRecs}, LogType: DeleteLog, LogBy: UserId, LogAt: logAt.Add(2 * time.Millisecond), PrevHash: audit2.Hash}
+	audit3.Hash = ComputeAuditHash(audit3, audit2.Hash)
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the same hash for the stored (json) record-values:",
+		TestFunc: func() {
+			stored := audit1
+			_ = stored.LogRecords.Scan(`{"url":"localhost:9000","cost":1000,"desc":"Testing only","name":"Abi","priority":1}`)
+			mctest.AssertEquals(t, ComputeAuditHash(stored, ""), audit1.Hash, "stored-entry hash should match")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should verify the valid chain:",
+		TestFunc: func() {
+			index, _ := VerifyAuditEntries([]Audit{audit1, audit2, audit3}, "")
+			mctest.AssertEquals(t, index, -1, "broken-index should be: -1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report the altered entry:",
+		TestFunc: func() {
+			altered := audit2
+			altered.LogBy = "someone-else"
+			index, _ := VerifyAuditEntries([]Audit{audit1, altered, audit3}, "")
+			mctest.AssertEquals(t, index, 1, "broken-index should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report the altered entry-id:",
+		TestFunc: func() {
+			altered := audit2
+			altered.ID = "a9"
+			index, _ := VerifyAuditEntries([]Audit{audit1, altered, audit3}, "")
+			mctest.AssertEquals(t, index, 1, "broken-index should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report the broken link, for a deleted entry:",
+		TestFunc: func() {
+			index, _ := VerifyAuditEntries([]Audit{audit1, audit3}, "")
+			mctest.AssertEquals(t, index, 1, "broken-index should be: 1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestAsyncAuditLog(t *testing.T) {
+	asyncLog := NewAsyncAuditLog(LogParam{AuditTable: "audits"}, AsyncAuditOptionsType{QueueSize: 1})
+	asyncLog.Close()
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should drop the entry enqueued after close:",
+		TestFunc: func() {
+			ok := asyncLog.Enqueue(Audit{TableName: GroupTable, LogType: CreateLog, LogBy: UserId, LogAt: time.Now()})
+			mctest.AssertEquals(t, ok, false, "enqueue should be: false")
+			mctest.AssertEquals(t, asyncLog.Dropped(), int64(1), "dropped-count should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the log-error for the dropped entry:",
+		TestFunc: func() {
+			log := LogParam{AuditTable: "audits", Async: asyncLog}
+			res, err := log.CreateLog(GroupTable, Recs, UserId)
+			mctest.AssertEquals(t, err != nil, true, "error should be: not nil")
+			mctest.AssertEquals(t, res.Code, "logError", "response-code should be: logError")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestAuditArchive(t *testing.T) {
+	logAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	audit1 := Audit{ID: "a1", TableName: GroupTable, LogRecords: JsonDataType{Data: Recs}, LogType: CreateLog, LogBy: UserId, LogAt: logAt}
+	audit1.Hash = ComputeAuditHash(audit1, "")
+	audit2 := Audit{ID: "a2", TableName: GroupTable, LogRecords: JsonDataType{Data: Recs}, NewLogRecords: JsonDataType{Data: NewRecs}, LogType: UpdateLog, LogBy: UserId, LogAt: logAt.Add(time.Millisecond), PrevHash: audit1.Hash}
+	audit2.Hash = ComputeAuditHash(audit2, audit1.Hash)
+	audit3 := Audit{ID: "a3", TableName: GroupTable, LogRecords: JsonDataType{Data: NewRecs}, LogType: DeleteLog, LogBy: UserId, LogAt: logAt.Add(2 * time.Millisecond), PrevHash: audit2.Hash}
+	audit3.Hash = ComputeAuditHash(audit3, audit2.Hash)
+
+	var buf bytes.Buffer
+	writeErr := WriteAuditArchive(&buf, []Audit{audit1, audit2, audit3})
+	var restored []Audit
+	batchCount := 0
+	readCount, readErr := ReadAuditArchive(&buf, 2, func(audits []Audit) error {
+		batchCount++
+		restored = append(restored, audits...)
+		return nil
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should read back all archived entries, in batches:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, writeErr, nil, "write-error should be: nil")
+			mctest.AssertEquals(t, readErr, nil, "read-error should be: nil")
+			mctest.AssertEquals(t, readCount, 3, "read-count should be: 3")
+			mctest.AssertEquals(t, batchCount, 2, "batch-count should be: 2")
+			mctest.AssertEquals(t, restored[1].ID, "a2", "second entry id should be: a2")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should verify the hash-chain of the restored entries:",
+		TestFunc: func() {
+			index, _ := VerifyAuditEntries(restored, "")
+			mctest.AssertEquals(t, index, -1, "broken-index should be: -1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should verify the remaining chain from its anchor, after purge:",
+		TestFunc: func() {
+			index, _ := VerifyAuditEntries(restored[1:], restored[1].PrevHash)
+			mctest.AssertEquals(t, index, -1, "broken-index should be: -1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestAuditSinks(t *testing.T) {
+	logDir := t.TempDir()
+	filePath := filepath.Join(logDir, "audits.log")
+	fileSink, fileErr := NewFileAuditSink(filePath, 200, 2)
+	var syslogBuf bytes.Buffer
+	syslogSink := &SyslogAuditSink{Writer: &syslogBuf, Hostname: "localhost", AppName: "mcgorm"}
+	fanOut := FanOutAuditSink{Sinks: []AuditLogger{fileSink, syslogSink}}
+	options := AuditLogOptionsType{TableName: GroupTable, LogRecords: Recs}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the audit-log entries to all sinks, and rotate the file:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, fileErr, nil, "file-sink error should be: nil")
+			for i := 0; i < 3; i++ {
+				res, err := fanOut.AuditLog(CreateLog, UserId, options)
+				mctest.AssertEquals(t, err, nil, "fan-out error should be: nil")
+				mctest.AssertEquals(t, res.Code, "success", "response-code should be: success")
+			}
+			_ = fileSink.Close()
+			_, statErr := os.Stat(filePath + ".1")
+			mctest.AssertEquals(t, statErr, nil, "rotated file should exist")
+			mctest.AssertEquals(t, strings.Count(syslogBuf.String(), "\n"), 3, "syslog messages should be: 3")
+			mctest.AssertEquals(t, strings.HasPrefix(syslogBuf.String(), "<110>1 "), true, "syslog priority/version should be: <110>1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set the new entry-id of the sink audit-log entries:",
+		TestFunc: func() {
+			var idBuf bytes.Buffer
+			idSink := &SyslogAuditSink{Writer: &idBuf, Hostname: "localhost", AppName: "mcgorm"}
+			var ids []string
+			for i := 0; i < 2; i++ {
+				_, err := idSink.AuditLog(CreateLog, UserId, options)
+				mctest.AssertEquals(t, err, nil, "syslog-sink error should be: nil")
+			}
+			for _, message := range strings.Split(strings.TrimSpace(idBuf.String()), "\n") {
+				var audit Audit
+				err := json.Unmarshal([]byte(message[strings.Index(message, " - ")+3:]), &audit)
+				mctest.AssertEquals(t, err, nil, "syslog-entry decode error should be: nil")
+				ids = append(ids, audit.ID)
+			}
+			mctest.AssertEquals(t, len(ids), 2, "syslog entries should be: 2")
+			mctest.AssertEquals(t, ids[0] != "" && ids[1] != "" && ids[0] != ids[1], true, "entry-ids should be: set and unique")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the log-type and the request information of the audit-log methods:",
+		TestFunc: func() {
+			queueLog := &AsyncAuditLog{queue: make(chan Audit, 10)}
+			log := LogParam{AuditTable: "audits", Async: queueLog}
+			requestMeta := RequestMetaType{ClientIp: "10.0.0.1", RequestId: "req-100"}
+			_, err := log.UpdateLog(GroupTable, Recs, NewRecs, UserId, requestMeta)
+			mctest.AssertEquals(t, err, nil, "update-log error should be: nil")
+			_, err = log.LogoutLog(Recs, UserId, "access_keys")
+			mctest.AssertEquals(t, err, nil, "logout-log error should be: nil")
+			audit := <-queueLog.queue
+			mctest.AssertEquals(t, audit.LogType, UpdateLog, "update-log type should be: update")
+			mctest.AssertEquals(t, audit.ClientIp, "10.0.0.1", "update-log client-ip should be: 10.0.0.1")
+			mctest.AssertEquals(t, audit.RequestId, "req-100", "update-log request-id should be: req-100")
+			audit = <-queueLog.queue
+			mctest.AssertEquals(t, audit.LogType, LogoutLog, "logout-log type should be: logout")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the params-error for the incomplete log information:",
+		TestFunc: func() {
+			res, err := fanOut.AuditLog(CreateLog, "", options)
+			mctest.AssertEquals(t, err != nil, true, "error should be: not nil")
+			mctest.AssertEquals(t, res.Code, "paramsError", "response-code should be: paramsError")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestComposeAuditRequestMeta(t *testing.T) {
+	audit, _, err := composeAudit(LoginLog, UserId, AuditLogOptionsType{
+		TableName:   "access_keys",
+		LogRecords:  map[string]interface{}{"loginName": "abbeymart"},
+		AppId:       "app-1",
+		RequestMeta: RequestMetaType{ClientIp: "10.0.0.1", UserAgent: "mctest", RequestId: "req-1", CorrelationId: "cor-1"},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should record the request information with the audit-log entry:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, err, nil, "error should be: nil")
+			mctest.AssertEquals(t, audit.AppId, "app-1", "appId should be: app-1")
+			mctest.AssertEquals(t, audit.ClientIp, "10.0.0.1", "clientIp should be: 10.0.0.1")
+			mctest.AssertEquals(t, audit.UserAgent, "mctest", "userAgent should be: mctest")
+			mctest.AssertEquals(t, audit.RequestId, "req-1", "requestId should be: req-1")
+			mctest.AssertEquals(t, audit.CorrelationId, "cor-1", "correlationId should be: cor-1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestAuditQuery(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should escape the LIKE wildcards of the record-id:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, escapeLike("id_1%!"), "id!_1!%!!", "escaped record-id should be: id!_1!%!!")
+			mctest.AssertEquals(t, escapeLike("g1"), "g1", "plain record-id should be: unchanged")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should include the change log-types only, for the record history:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, strings.Join(ChangeLogTypes, ","), "create,update,delete,remove", "change log-types should be: create,update,delete,remove")
+			mctest.AssertEquals(t, ArrayStringContains(ChangeLogTypes, ReadLog), false, "read log-type should be: excluded")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,362 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - unique and foreign-key checks, and database constraint-error translation
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ConstraintErrorType describes a unique or foreign-key constraint violation
+type ConstraintErrorType struct {
+	Constraint string      `json:"constraint"` // unique | foreignKey
+	Field      string      `json:"field"`      // table-column
+	Value      interface{} `json:"value"`
+	Table      string      `json:"table"` // referenced table, for foreignKey
+	Message    string      `json:"message"`
+}
+
+// constraint types
+const (
+	UniqueConstraint     = "unique"
+	ForeignKeyConstraint = "foreignKey"
+)
+
+var (
+	pgKeyDetailPattern     = regexp.MustCompile(`Key \((.+?)\)=\((.*?)\)`)
+	pgTableDetailPattern   = regexp.MustCompile(`in table "(.+?)"`)
+	mysqlDuplicatePattern  = regexp.MustCompile(`Duplicate entry '(.*?)' for key '(.+?)'`)
+	mysqlForeignKeyPattern = regexp.MustCompile("FOREIGN KEY \\(`(.+?)`\\) REFERENCES `(.+?)`")
+	sqliteUniquePattern    = regexp.MustCompile(`UNIQUE constraint failed: ([\w.]+(?:, [\w.]+)*)`)
+)
+
+// TranslateDbError translates the database (Postgres, MySQL, SQLite) unique/foreign-key constraint-error
+// into the ConstraintErrorType; ok is false for the other errors
+func TranslateDbError(err error) (constraintErr ConstraintErrorType, ok bool) {
+	if err == nil {
+		return constraintErr, false
+	}
+	// Postgres
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			constraintErr.Constraint = UniqueConstraint
+		case "23503":
+			constraintErr.Constraint = ForeignKeyConstraint
+		default:
+			return constraintErr, false
+		}
+		if matches := pgKeyDetailPattern.FindStringSubmatch(pgErr.Detail); len(matches) == 3 {
+			constraintErr.Field = matches[1]
+			constraintErr.Value = matches[2]
+		} else {
+			constraintErr.Field = pgErr.ColumnName
+		}
+		if matches := pgTableDetailPattern.FindStringSubmatch(pgErr.Detail); len(matches) == 2 && constraintErr.Constraint == ForeignKeyConstraint {
+			constraintErr.Table = matches[1]
+		}
+		constraintErr.Message = pgErr.Detail
+		return constraintErr, true
+	}
+	errMsg := err.Error()
+	// MySQL
+	if strings.Contains(errMsg, "Error 1062") {
+		constraintErr.Constraint = UniqueConstraint
+		if matches := mysqlDuplicatePattern.FindStringSubmatch(errMsg); len(matches) == 3 {
+			constraintErr.Value = matches[1]
+			keyName := matches[2]
+			constraintErr.Field = keyName[strings.LastIndex(keyName, ".")+1:]
+		}
+		constraintErr.Message = errMsg
+		return constraintErr, true
+	}
+	if strings.Contains(errMsg, "Error 1452") {
+		constraintErr.Constraint = ForeignKeyConstraint
+		if matches := mysqlForeignKeyPattern.FindStringSubmatch(errMsg); len(matches) == 3 {
+			constraintErr.Field = matches[1]
+			constraintErr.Table = matches[2]
+		}
+		constraintErr.Message = errMsg
+		return constraintErr, true
+	}
+	// SQLite
+	if matches := sqliteUniquePattern.FindStringSubmatch(errMsg); len(matches) == 2 {
+		constraintErr.Constraint = UniqueConstraint
+		var fields []string
+		for _, tableField := range strings.Split(matches[1], ", ") {
+			fields = append(fields, tableField[strings.LastIndex(tableField, ".")+1:])
+		}
+		constraintErr.Field = strings.Join(fields, ", ")
+		constraintErr.Message = errMsg
+		return constraintErr, true
+	}
+	if strings.Contains(errMsg, "FOREIGN KEY constraint failed") {
+		constraintErr.Constraint = ForeignKeyConstraint
+		constraintErr.Message = errMsg
+		return constraintErr, true
+	}
+	return constraintErr, false
+}
+
+// constraintMessage composes the response message for the constraint-errors
+func (crud Crud) constraintMessage(constraintErrors []ConstraintErrorType) string {
+	var messages []string
+	for _, constraintErr := range constraintErrors {
+		fieldValue := constraintErr.Field
+		if constraintErr.Value != nil {
+			fieldValue = fmt.Sprintf("%v = %v", constraintErr.Field, constraintErr.Value)
+		}
+		switch constraintErr.Constraint {
+		case UniqueConstraint:
+			messages = append(messages, fmt.Sprintf("%v: %v", crud.RecExistMessage, fieldValue))
+		default:
+			messages = append(messages, fmt.Sprintf("Referenced record does not exist: %v (%v)", fieldValue, constraintErr.Table))
+		}
+	}
+	return strings.Join(messages, " | ")
+}
+
+// ConstraintErrorMessage returns the response for the constraint-errors: exists (unique) or paramsError (foreignKey)
+func (crud Crud) ConstraintErrorMessage(constraintErrors []ConstraintErrorType) mcresponse.ResponseMessage {
+	msgType := "paramsError"
+	for _, constraintErr := range constraintErrors {
+		if constraintErr.Constraint == UniqueConstraint {
+			msgType = "exists"
+			break
+		}
+	}
+	return mcresponse.GetResMessage(msgType, mcresponse.ResponseMessageOptions{
+		Message: crud.constraintMessage(constraintErrors),
+		Value:   constraintErrors,
+	})
+}
+
+// DbErrorMessage returns the constraint-error response, for the database constraint-error, or the response of the
+// crud-error (DbError): connectError, for the connection-error, or the msgType (e.g. insertError, updateError),
+// for the other errors
+func (crud Crud) DbErrorMessage(err error, msgType string) mcresponse.ResponseMessage {
+	dbErr := DbError(err, msgType)
+	if constraintErr, ok := TranslateDbError(err); ok {
+		crud.recordTaskError(dbErr)
+		return crud.ConstraintErrorMessage([]ConstraintErrorType{constraintErr})
+	}
+	return crud.ErrorResponse(dbErr)
+}
+
+// recordValues returns the struct values of the record(s): struct, pointer to struct or slice of struct
+func recordValues(recs interface{}) []reflect.Value {
+	var values []reflect.Value
+	if recs == nil {
+		return values
+	}
+	recsValue := reflect.Indirect(reflect.ValueOf(recs))
+	var items []reflect.Value
+	switch recsValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < recsValue.Len(); i++ {
+			items = append(items, recsValue.Index(i))
+		}
+	default:
+		items = append(items, recsValue)
+	}
+	for _, item := range items {
+		for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+			if item.IsNil() {
+				break
+			}
+			item = item.Elem()
+		}
+		if item.Kind() == reflect.Struct {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// uniqueFieldSets returns the unique-field sets (unique fields and unique-indexes) of the model-schema
+func uniqueFieldSets(modelSchema *schema.Schema) [][]*schema.Field {
+	var fieldSets [][]*schema.Field
+	for _, field := range modelSchema.Fields {
+		if field.Unique && !field.PrimaryKey && field.DBName != "" {
+			fieldSets = append(fieldSets, []*schema.Field{field})
+		}
+	}
+	for _, index := range modelSchema.ParseIndexes() {
+		if index.Class != "UNIQUE" {
+			continue
+		}
+		var fields []*schema.Field
+		for _, indexField := range index.Fields {
+			fields = append(fields, indexField.Field)
+		}
+		fieldSets = append(fieldSets, fields)
+	}
+	return fieldSets
+}
+
+// UpdateRecordIds method returns the ids of the records to update, by recordIds or queryParams
+func (crud *Crud) UpdateRecordIds(modelRef interface{}) ([]string, error) {
+	if len(crud.RecordIds) > 0 {
+		return crud.RecordIds, nil
+	}
+	var ids []string
+	if len(crud.QueryParams) > 0 {
+		qString, _, qValues, qErr := crud.ComputeWhereQuery()
+		if qErr != nil {
+			return nil, qErr
+		}
+		if err := crud.GormDb.Scopes(crud.AppScope).Model(modelRef).Where(qString, qValues...).Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// CheckConstraints method checks the declared unique fields (gorm unique tag / uniqueIndex) and the referenced
+// parent records (belongs-to foreign-keys) of the record(s), before save. The excludeIds (updated records) and the
+// record's own id are excluded from the unique checks. The unique checks are global (all apps/tenants and the
+// soft-deleted records), as the database unique constraints; include the app_id in the uniqueIndex, for the per-tenant
+// unique values. A unique value of the (single) update record is rejected, for the multiple updated records.
+func (crud Crud) CheckConstraints(modelRef interface{}, recs interface{}, excludeIds []string) mcresponse.ResponseMessage {
+	stmt := &gorm.Statement{DB: crud.GormDb}
+	if err := stmt.Parse(modelRef); err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", err.Error()),
+			Value:   nil,
+		})
+	}
+	modelSchema := stmt.Schema
+	var constraintErrors []ConstraintErrorType
+	records := recordValues(recs)
+	// unique fields
+	for _, fieldSet := range uniqueFieldSets(modelSchema) {
+		batchValues := map[string]bool{}
+		for _, record := range records {
+			query := crud.GormDb.Unscoped().Model(modelRef)
+			var fieldNames []string
+			var fieldValues []string
+			skip := false
+			for _, field := range fieldSet {
+				value, isZero := field.ValueOf(record)
+				if isZero {
+					skip = true
+					break
+				}
+				query = query.Where(fmt.Sprintf("%v = ?", field.DBName), value)
+				fieldNames = append(fieldNames, field.DBName)
+				fieldValues = append(fieldValues, fmt.Sprintf("%v", value))
+			}
+			if skip {
+				continue
+			}
+			constraintErr := ConstraintErrorType{
+				Constraint: UniqueConstraint,
+				Field:      strings.Join(fieldNames, ", "),
+				Value:      strings.Join(fieldValues, ", "),
+			}
+			// same unique value for the multiple updated records, e.g. by queryParams
+			if len(records) == 1 && len(excludeIds) > 1 {
+				return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("Unique field(s) %v cannot be set to the same value for the %v updated records", constraintErr.Field, len(excludeIds)),
+					Value:   nil,
+				})
+			}
+			// duplicate values within the records
+			batchKey := strings.Join(fieldValues, "\x00")
+			if batchValues[batchKey] {
+				constraintErr.Message = "duplicate value in the records"
+				constraintErrors = append(constraintErrors, constraintErr)
+				continue
+			}
+			batchValues[batchKey] = true
+			ids := excludeIds
+			if modelSchema.PrioritizedPrimaryField != nil {
+				if id, idZero := modelSchema.PrioritizedPrimaryField.ValueOf(record); !idZero {
+					ids = append(append([]string{}, excludeIds...), fmt.Sprintf("%v", id))
+				}
+			}
+			if len(ids) > 0 {
+				query = query.Where("id NOT IN ?", ids)
+			}
+			var count int64
+			if err := query.Count(&count).Error; err != nil {
+				return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", err.Error()),
+					Value:   nil,
+				})
+			}
+			if count > 0 {
+				constraintErr.Message = crud.RecExistMessage
+				constraintErrors = append(constraintErrors, constraintErr)
+			}
+		}
+	}
+	// referenced parent records (belongs-to)
+	for _, relation := range modelSchema.Relationships.Relations {
+		if relation.Type != schema.BelongsTo {
+			continue
+		}
+		for _, record := range records {
+			query := crud.GormDb.Table(relation.FieldSchema.Table)
+			var fieldNames []string
+			var fieldValues []string
+			skip := false
+			for _, reference := range relation.References {
+				if reference.PrimaryKey == nil || reference.ForeignKey == nil {
+					continue
+				}
+				value, isZero := reference.ForeignKey.ValueOf(record)
+				if isZero {
+					skip = true
+					break
+				}
+				if ptrValue := reflect.ValueOf(value); ptrValue.Kind() == reflect.Ptr {
+					if ptrValue.IsNil() {
+						skip = true
+						break
+					}
+					value = ptrValue.Elem().Interface()
+				}
+				query = query.Where(fmt.Sprintf("%v = ?", reference.PrimaryKey.DBName), value)
+				fieldNames = append(fieldNames, reference.ForeignKey.DBName)
+				fieldValues = append(fieldValues, fmt.Sprintf("%v", value))
+			}
+			if skip || len(fieldNames) < 1 {
+				continue
+			}
+			var count int64
+			if err := query.Count(&count).Error; err != nil {
+				return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", err.Error()),
+					Value:   nil,
+				})
+			}
+			if count < 1 {
+				constraintErrors = append(constraintErrors, ConstraintErrorType{
+					Constraint: ForeignKeyConstraint,
+					Field:      strings.Join(fieldNames, ", "),
+					Value:      strings.Join(fieldValues, ", "),
+					Table:      relation.FieldSchema.Table,
+					Message:    "referenced record does not exist",
+				})
+			}
+		}
+	}
+	if len(constraintErrors) > 0 {
+		return crud.ConstraintErrorMessage(constraintErrors)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Constraint checks completed successfully",
+		Value:   nil,
+	})
+}
@@ -0,0 +1,98 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: unique / foreign-key constraint-error test cases
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mctest"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTranslateDbError(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should translate the Postgres unique and foreign-key errors:",
+		TestFunc: func() {
+			pgErr := &pgconn.PgError{Code: "23505", Detail: "Key (name)=(Abi) already exists."}
+			res, ok := TranslateDbError(fmt.Errorf("insert error: %w", pgErr))
+			mctest.AssertEquals(t, ok, true, "translated should be: true")
+			mctest.AssertEquals(t, res.Constraint, UniqueConstraint, "constraint should be: unique")
+			mctest.AssertEquals(t, res.Field, "name", "field should be: name")
+			mctest.AssertEquals(t, res.Value, "Abi", "value should be: Abi")
+			pgErr = &pgconn.PgError{Code: "23503", Detail: `Key (group_id)=(g-100) is not present in table "groups".`}
+			res, ok = TranslateDbError(pgErr)
+			mctest.AssertEquals(t, res.Constraint, ForeignKeyConstraint, "constraint should be: foreignKey")
+			mctest.AssertEquals(t, res.Field, "group_id", "field should be: group_id")
+			mctest.AssertEquals(t, res.Table, "groups", "table should be: groups")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should translate the MySQL unique and foreign-key errors:",
+		TestFunc: func() {
+			res, ok := TranslateDbError(errors.New("Error 1062: Duplicate entry 'Abi' for key 'groups.name'"))
+			mctest.AssertEquals(t, ok, true, "translated should be: true")
+			mctest.AssertEquals(t, res.Field, "name", "field should be: name")
+			mctest.AssertEquals(t, res.Value, "Abi", "value should be: Abi")
+			res, _ = TranslateDbError(errors.New("Error 1452: Cannot add or update a child row: a foreign key constraint fails (`mcdb`.`categories`, CONSTRAINT `fk_categories_group` FOREIGN KEY (`group_id`) REFERENCES `groups` (`id`))"))
+			mctest.AssertEquals(t, res.Constraint, ForeignKeyConstraint, "constraint should be: foreignKey")
+			mctest.AssertEquals(t, res.Field, "group_id", "field should be: group_id")
+			mctest.AssertEquals(t, res.Table, "groups", "table should be: groups")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should translate the SQLite unique error, and ignore the other errors:",
+		TestFunc: func() {
+			res, ok := TranslateDbError(errors.New("UNIQUE constraint failed: groups.name"))
+			mctest.AssertEquals(t, ok, true, "translated should be: true")
+			mctest.AssertEquals(t, res.Field, "name", "field should be: name")
+			_, ok = TranslateDbError(errors.New("connection refused"))
+			mctest.AssertEquals(t, ok, false, "translated should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the exists response, with the RecExistMessage:",
+		TestFunc: func() {
+			crud := Crud{}
+			crud.RecExistMessage = "Record already exists"
+			res := crud.DbErrorMessage(errors.New("UNIQUE constraint failed: groups.name"), "insertError")
+			mctest.AssertEquals(t, res.Code, "exists", "response-code should be: exists")
+			mctest.AssertEquals(t, strings.HasSuffix(res.Message, "Record already exists: name"), true, "response-message should end with: Record already exists: name")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestUniqueFieldSets(t *testing.T) {
+	groupSchema, err := schema.Parse(&Group{}, &sync.Map{}, schema.NamingStrategy{})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the declared unique fields:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, err, nil, "schema-parse error should be: nil")
+			fieldSets := uniqueFieldSets(groupSchema)
+			mctest.AssertEquals(t, len(fieldSets), 1, "unique field-sets should be: 1")
+			mctest.AssertEquals(t, fieldSets[0][0].DBName, "name", "unique field should be: name")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the unique value, for the multiple updated records:",
+		TestFunc: func() {
+			db, err := gorm.Open(nil, &gorm.Config{DryRun: true})
+			mctest.AssertEquals(t, err, nil, "gorm-open error should be: nil")
+			crud := Crud{}
+			crud.GormDb = db
+			res := crud.CheckConstraints(&Group{}, []Group{{Name: "services"}}, []string{"g1", "g2"})
+			mctest.AssertEquals(t, res.Code, "paramsError", "response-code should be: paramsError")
+			mctest.AssertEquals(t, strings.Contains(res.Message, "2 updated records"), true, "response-message should contain: 2 updated records")
+		},
+	})
+
+	mctest.PostTestResult()
+}
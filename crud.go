@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"strings"
 )
 
 // Crud object / struct
@@ -15,8 +17,9 @@ type Crud struct {
 	CrudParamsType
 	CrudOptionsType
 	CurrentRecords []interface{}
-	TransLog       LogParam
+	TransLog       AuditLogger
 	CacheKey       string // Unique for exactly the same query
+	taskErr        *error // crud-error (with the wrapped cause) of the crud-task, for the Go-native crud methods
 }
 
 // NewCrud constructor returns a new crud-instance
@@ -32,10 +35,13 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.QueryParams = params.QueryParams
 	crudInstance.SortParams = params.SortParams
 	crudInstance.ProjectParams = params.ProjectParams
+	crudInstance.IncludeParams = params.IncludeParams
 	crudInstance.Token = params.Token
 	crudInstance.TaskName = params.TaskName
 	crudInstance.Skip = params.Skip
 	crudInstance.Limit = params.Limit
+	crudInstance.AppParams = params.AppParams
+	crudInstance.RequestMeta = params.RequestMeta
 
 	// crud options
 	crudInstance.MaxQueryLimit = options.MaxQueryLimit
@@ -45,6 +51,11 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.UserTable = options.UserTable
 	crudInstance.ProfileTable = options.ProfileTable
 	crudInstance.ServiceTable = options.ServiceTable
+	crudInstance.AppTable = options.AppTable
+	crudInstance.VerifyTable = options.VerifyTable
+	crudInstance.RoleInheritTable = options.RoleInheritTable
+	crudInstance.RoleInheritance = options.RoleInheritance
+	crudInstance.MaxRoleDepth = options.MaxRoleDepth
 	crudInstance.AuditDb = options.AuditDb
 	crudInstance.AccessDb = options.AccessDb
 	crudInstance.ServiceDb = options.ServiceDb
@@ -56,14 +67,36 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.LogCreate = options.LogCreate
 	crudInstance.LogUpdate = options.LogUpdate
 	crudInstance.LogDelete = options.LogDelete
+	crudInstance.LogDiffOnly = options.LogDiffOnly
+	crudInstance.AuditHashChain = options.AuditHashChain
+	crudInstance.AsyncAudit = options.AsyncAudit
+	crudInstance.AuditLogger = options.AuditLogger
+	crudInstance.HistoryTables = options.HistoryTables
+	crudInstance.StrictNotFound = options.StrictNotFound
+	crudInstance.Hooks = options.Hooks
+	crudInstance.Outbox = options.Outbox
+	crudInstance.OutboxTable = options.OutboxTable
+	crudInstance.IdType = options.IdType
+	crudInstance.TreeTables = options.TreeTables
+	crudInstance.RecExistMessage = options.RecExistMessage
+	crudInstance.LogLogin = options.LogLogin
+	crudInstance.LogLogout = options.LogLogout
+	crudInstance.LoginTimeout = options.LoginTimeout
+	crudInstance.SlidingExpiry = options.SlidingExpiry
+	crudInstance.VerifyTimeout = options.VerifyTimeout
+	crudInstance.UsernameExistsMessage = options.UsernameExistsMessage
+	crudInstance.EmailExistsMessage = options.EmailExistsMessage
+	crudInstance.MsgFrom = options.MsgFrom
+	crudInstance.Mailer = options.Mailer
 	crudInstance.CheckAccess = options.CheckAccess // Dec 09/2020: user to implement auth as a middleware
 	crudInstance.CacheExpire = options.CacheExpire // cache expire in secs
+	crudInstance.CacheAccess = options.CacheAccess
 	// Compute CacheKey from TableName, QueryParams, SortParams, ProjectParams and RecordIds
 	qParam, _ := json.Marshal(params.QueryParams)
 	sParam, _ := json.Marshal(params.SortParams)
 	pParam, _ := json.Marshal(params.ProjectParams)
 	dIds, _ := json.Marshal(params.RecordIds)
-	crudInstance.CacheKey = params.TableName + string(qParam) + string(sParam) + string(pParam) + string(dIds)
+	crudInstance.CacheKey = params.AppParams.AppId + params.TableName + string(qParam) + string(sParam) + string(pParam) + string(dIds)
 
 	// Default values
 	if crudInstance.AuditTable == "" {
@@ -84,6 +117,18 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.ServiceTable == "" {
 		crudInstance.ServiceTable = "services"
 	}
+	if crudInstance.AppTable == "" {
+		crudInstance.AppTable = "apps"
+	}
+	if crudInstance.VerifyTable == "" {
+		crudInstance.VerifyTable = "verify_users"
+	}
+	if crudInstance.RoleInheritTable == "" {
+		crudInstance.RoleInheritTable = "role_inherits"
+	}
+	if crudInstance.MaxRoleDepth <= 0 {
+		crudInstance.MaxRoleDepth = 10
+	}
 	if crudInstance.AuditDb == nil {
 		crudInstance.AuditDb = crudInstance.AppDb
 	}
@@ -114,8 +159,36 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.CacheExpire <= 0 {
 		crudInstance.CacheExpire = 300 // 300 secs, 5 minutes
 	}
+	if crudInstance.LoginTimeout <= 0 {
+		crudInstance.LoginTimeout = 3600 // 3600 secs, 1 hour
+	}
+	if crudInstance.VerifyTimeout <= 0 {
+		crudInstance.VerifyTimeout = 86400 // 86400 secs, 1 day
+	}
+	if crudInstance.IdType == "" {
+		crudInstance.IdType = UuidV4Id
+	}
+	if crudInstance.OutboxTable == "" {
+		crudInstance.OutboxTable = DefaultOutboxTable
+	}
+	if crudInstance.RecExistMessage == "" {
+		crudInstance.RecExistMessage = "Save / update error: record already exists"
+	}
+	if crudInstance.UsernameExistsMessage == "" {
+		crudInstance.UsernameExistsMessage = "Username already exists. Provide a different username"
+	}
+	if crudInstance.EmailExistsMessage == "" {
+		crudInstance.EmailExistsMessage = "Email already exists. Provide a different email, or login"
+	}
 	// Audit/TransLog instance
-	crudInstance.TransLog = NewAuditLog(crudInstance.GormAuditDb, crudInstance.AuditTable)
+	if options.AuditLogger != nil {
+		crudInstance.TransLog = options.AuditLogger
+	} else {
+		transLog := NewAuditLog(crudInstance.GormAuditDb, crudInstance.AuditTable)
+		transLog.HashChain = options.AuditHashChain
+		transLog.Async = options.AsyncAudit
+		crudInstance.TransLog = transLog
+	}
 
 	return crudInstance
 }
@@ -127,53 +200,85 @@ func (crud Crud) String() string {
 
 // Methods
 
-// SaveRecord function creates new record(s) or updates existing record(s)
+// SaveRecord function creates new record(s) or updates existing record(s), with the create/update hooks of the table
 func (crud *Crud) SaveRecord(modelRef interface{}, recs interface{}, batch int) mcresponse.ResponseMessage {
+	// check app/tenant access and task-permission - create/update, before the hooks, validation and constraints queries
+	if crud.TaskType == CrudTasks().Create || crud.TaskType == CrudTasks().Update {
+		if accessRes := crud.taskAccess(crud.TaskType); accessRes.Code != "success" {
+			return accessRes
+		}
+	}
+	// invalidate cached access-decisions, on access-control tables changes, after the hooks transaction commit
+	defer crud.InvalidateTableAccessCache()
+	return crud.RunWithHooks(crud.TaskType, modelRef, recs, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+		return taskCrud.saveRecord(modelRef, taskRecs, batch)
+	})
+}
+
+// saveRecord function creates new record(s) or updates existing record(s)
+func (crud *Crud) saveRecord(modelRef interface{}, recs interface{}, batch int) mcresponse.ResponseMessage {
+	// app/tenant access and task-permission checked by SaveRecord
+	// validate the record(s), by the model validate-tags
+	if validateRes := ValidateRecords(recs); !validateRes.Ok {
+		return GetParamsMessage(validateRes.Errors, "paramsError")
+	}
+	// check the unique fields and the referenced records
+	var excludeIds []string
+	if crud.TaskType == CrudTasks().Update {
+		updateIds, err := crud.UpdateRecordIds(modelRef)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("%v", err.Error()),
+				Value:   nil,
+			})
+		}
+		excludeIds = updateIds
+	}
+	if constraintRes := crud.CheckConstraints(modelRef, recs, excludeIds); constraintRes.Code != "success" {
+		return constraintRes
+	}
 	// default value
 	if batch == 0 {
 		batch = 10000
 	}
 	// create/insert new record(s)
 	if crud.TaskType == CrudTasks().Create {
-		// check task-permission
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermission(crud.TaskType)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
-		}
 		// save-record(s): create/insert new record(s): len(recordIds) = 0 && len(createRecs) > 0
 		return crud.CreateBatch(recs, batch)
 	}
 
 	if crud.TaskType == CrudTasks().Update {
-		// check task-permission
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermission(crud.TaskType)
-			if accessRes.Code != "success" {
-				return accessRes
+		// tree-table: check the new parents for the tree-cycles
+		var treeIds []string
+		if crud.TreeEnabled() {
+			nodeIds, treeRes := crud.CheckTreeUpdate(modelRef, recs, excludeIds)
+			if treeRes.Code != "success" {
+				return treeRes
 			}
+			treeIds = nodeIds
 		}
-		// update 1 or more records by ids or queryParams
-		if len(crud.ActionParams) == 1 {
-			upRec := recs.([]interface{})[0]
-			// update record(s) by recordIds
-			if len(crud.RecordIds) > 1 {
-				return crud.UpdateByIds(modelRef, upRec)
-			}
-			// update the record by recordId
-			if len(crud.RecordIds) == 1 {
-				return crud.UpdateById(modelRef, upRec, crud.RecordIds[0])
+		if len(treeIds) < 1 {
+			return crud.updateRecord(modelRef, recs)
+		}
+		// tree-table: update the records and recompute the materialized paths of the updated nodes and their
+		// descendants, in a transaction
+		var updateRes mcresponse.ResponseMessage
+		err := crud.GormDb.Transaction(func(tx *gorm.DB) error {
+			txCrud := *crud
+			txCrud.GormDb = tx
+			updateRes = txCrud.updateRecord(modelRef, recs)
+			if updateRes.Code != "success" {
+				return ResponseToError(updateRes)
 			}
-			// update record(s) by queryParams
-			if len(crud.QueryParams) > 0 {
-				return crud.UpdateByParam(modelRef, upRec)
+			return txCrud.UpdateTreePaths(treeIds)
+		})
+		if err != nil {
+			if updateRes.Code != "" && updateRes.Code != "success" {
+				return updateRes
 			}
+			return crud.DbErrorMessage(err, "updateError")
 		}
-		// update multiple records
-		if len(crud.ActionParams) > 1 {
-			return crud.Update(modelRef, recs)
-		}
+		return updateRes
 	}
 
 	// otherwise return saveError
@@ -183,8 +288,38 @@ func (crud *Crud) SaveRecord(modelRef interface{}, recs interface{}, batch int)
 	})
 }
 
+// updateRecord function updates 1 or more records by ids or queryParams, or multiple records
+func (crud *Crud) updateRecord(modelRef interface{}, recs interface{}) mcresponse.ResponseMessage {
+	if len(crud.ActionParams) == 1 {
+		upRec := recs.([]interface{})[0]
+		// update record(s) by recordIds
+		if len(crud.RecordIds) > 1 {
+			return crud.UpdateByIds(modelRef, upRec)
+		}
+		// update the record by recordId
+		if len(crud.RecordIds) == 1 {
+			return crud.UpdateById(modelRef, upRec, crud.RecordIds[0])
+		}
+		// update record(s) by queryParams
+		if len(crud.QueryParams) > 0 {
+			return crud.UpdateByParam(modelRef, upRec)
+		}
+	}
+	// update multiple records
+	if len(crud.ActionParams) > 1 {
+		return crud.Update(modelRef, recs)
+	}
+	// otherwise return saveError
+	return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+		Message: "Save error: incomplete or invalid action/query-params provided",
+		Value:   nil,
+	})
+}
+
 // SaveRecord1 function creates new record(s) or updates existing record(s)
 func (crud *Crud) SaveRecord1(modelRef interface{}, recs interface{}, batch int) mcresponse.ResponseMessage {
+	// invalidate cached access-decisions, on access-control tables changes
+	defer crud.InvalidateTableAccessCache()
 	// default value
 	if batch == 0 {
 		batch = 10000
@@ -224,29 +359,41 @@ func (crud *Crud) SaveRecord1(modelRef interface{}, recs interface{}, batch int)
 			Value:   nil,
 		})
 	}
-
-	// create/insert new record(s)
+	// check app/tenant access and task-permission - create/update, before the validation and constraints queries
 	if len(createRecs) > 0 {
-		// check task-permission - create
 		crud.TaskType = CrudTasks().Create
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermission(crud.TaskType)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+	} else {
+		crud.TaskType = CrudTasks().Update
+	}
+	if accessRes := crud.taskAccess(crud.TaskType); accessRes.Code != "success" {
+		return accessRes
+	}
+	// validate the record(s), by the model validate-tags
+	if validateRes := ValidateRecords(recs); !validateRes.Ok {
+		return GetParamsMessage(validateRes.Errors, "paramsError")
+	}
+	// check the unique fields and the referenced records
+	var excludeIds []string
+	if len(updateRecs) > 0 {
+		updateIds, err := crud.UpdateRecordIds(modelRef)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("%v", err.Error()),
+				Value:   nil,
+			})
 		}
+		excludeIds = updateIds
+	}
+	if constraintRes := crud.CheckConstraints(modelRef, recs, excludeIds); constraintRes.Code != "success" {
+		return constraintRes
+	}
+
+	// create/insert new record(s)
+	if len(createRecs) > 0 {
 		// save-record(s): create/insert new record(s): len(recordIds) = 0 && len(createRecs) > 0
 		return crud.CreateBatch(recs, batch)
 	}
 
-	// check task-permission - for update task
-	crud.TaskType = CrudTasks().Update
-	if crud.CheckAccess {
-		accessRes := crud.TaskPermission(crud.TaskType)
-		if accessRes.Code != "success" {
-			return accessRes
-		}
-	}
 	// update 1 or more records by ids or queryParams
 	if len(updateRecs) == 1 {
 		// update the record by recordId
@@ -274,15 +421,31 @@ func (crud *Crud) SaveRecord1(modelRef interface{}, recs interface{}, batch int)
 	})
 }
 
-// DeleteRecord function deletes/removes record(s) by id(s) or params
+// DeleteRecord function deletes/removes record(s) by id(s) or params, with the delete hooks of the table
 func (crud *Crud) DeleteRecord(modelRef interface{}) mcresponse.ResponseMessage {
-	// check task-permission - delete
-	if crud.CheckAccess {
-		accessRes := crud.TaskPermission(CrudTasks().Delete)
-		if accessRes.Code != "success" {
-			return accessRes
+	// check app/tenant access and task-permission - delete, before the tree-check of the records
+	if accessRes := crud.taskAccess(CrudTasks().Delete); accessRes.Code != "success" {
+		return accessRes
+	}
+	// invalidate cached access-decisions, on access-control tables changes, after the hooks transaction commit
+	defer crud.InvalidateTableAccessCache()
+	deleteCrud := crud
+	// tree-table: block the delete of the records with child-records, or cascade to the descendants
+	if crud.TreeEnabled() {
+		treeCrud, treeRes := crud.TreeDeleteCrud(modelRef)
+		if treeRes.Code != "success" {
+			return treeRes
 		}
+		deleteCrud = treeCrud
 	}
+	return deleteCrud.RunWithHooks(CrudTasks().Delete, modelRef, nil, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+		return taskCrud.deleteRecord(modelRef)
+	})
+}
+
+// deleteRecord function deletes/removes record(s) by id(s) or params
+func (crud *Crud) deleteRecord(modelRef interface{}) mcresponse.ResponseMessage {
+	// app/tenant access and task-permission checked by DeleteRecord
 	if len(crud.RecordIds) == 1 {
 		return crud.DeleteById(modelRef, crud.RecordIds[0])
 	}
@@ -300,15 +463,20 @@ func (crud *Crud) DeleteRecord(modelRef interface{}) mcresponse.ResponseMessage
 	})
 }
 
-// GetRecord function get records by id, params or all
+// GetRecord function get records by id, params or all, with the read hooks of the table
 func (crud *Crud) GetRecord(modelRef interface{}) mcresponse.ResponseMessage {
-	// check task-permission - get/read
-	if crud.CheckAccess {
-		accessRes := crud.TaskPermission(CrudTasks().Read)
-		if accessRes.Code != "success" {
-			return accessRes
-		}
+	// check app/tenant access and task-permission - get/read, before the hooks
+	if accessRes := crud.taskAccess(CrudTasks().Read); accessRes.Code != "success" {
+		return accessRes
 	}
+	return crud.RunWithHooks(CrudTasks().Read, modelRef, nil, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+		return taskCrud.getRecord(modelRef)
+	})
+}
+
+// getRecord function get records by id, params or all
+func (crud *Crud) getRecord(modelRef interface{}) mcresponse.ResponseMessage {
+	// app/tenant access and task-permission checked by GetRecord
 	if len(crud.RecordIds) == 1 {
 		return crud.GetById(modelRef, crud.RecordIds[0])
 	}
@@ -335,6 +503,61 @@ func (crud *Crud) GetRecords(modelRef interface{}) mcresponse.ResponseMessage {
 	return crud.GetAll(modelRef)
 }
 
+// AppScope method restricts the query to the records of the current app/tenant (appId), if specified
+func (crud Crud) AppScope(db *gorm.DB) *gorm.DB {
+	if crud.AppParams.AppId == "" {
+		return db
+	}
+	return db.Where("app_id = ?", crud.AppParams.AppId)
+}
+
+// taskAccess method checks the app/tenant access and the task-permission (CheckAccess) of the crud-task
+func (crud *Crud) taskAccess(taskType string) mcresponse.ResponseMessage {
+	if crud.AppParams.AppId != "" {
+		appRes := crud.CheckAppAccess()
+		if appRes.Code != "success" {
+			return appRes
+		}
+	}
+	if crud.CheckAccess {
+		accessRes := crud.TaskPermission(taskType)
+		if accessRes.Code != "success" {
+			return accessRes
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Access permitted",
+		Value:   nil,
+	})
+}
+
+// MissingRecordIds method returns the record-ids (of the ids) not found in the table, for the current app/tenant
+func (crud Crud) MissingRecordIds(ids []string) ([]string, error) {
+	var foundIds []string
+	if len(ids) < 1 {
+		return foundIds, nil
+	}
+	result := crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).Where("id IN ?", ids).Pluck("id", &foundIds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var missingIds []string
+	for _, id := range ids {
+		if !ArrayStringContains(foundIds, id) && !ArrayStringContains(missingIds, id) {
+			missingIds = append(missingIds, id)
+		}
+	}
+	return missingIds, nil
+}
+
+// NotFoundMessage method returns the notFound response, for the missing record-ids
+func (crud Crud) NotFoundMessage(ids []string) mcresponse.ResponseMessage {
+	return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Record(s) not found: %v", strings.Join(ids, ", ")),
+		Value:   ids,
+	})
+}
+
 // ComputeWhereQuery method extracts query-fields and associated values
 func (crud *Crud) ComputeWhereQuery() (qString string, qFields []string, qValues []interface{}, qErr error) {
 	// transform queryParams to underscore map[string]interface{}
@@ -0,0 +1,220 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - typed crud-errors, mcresponse mapping and Go-native crud methods
+
+package mcgorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"io"
+	"net"
+	"strings"
+)
+
+// Crud-error codes (sentinels), for errors.Is, e.g. errors.Is(err, ErrNotFound)
+var (
+	ErrNotFound     = ErrorType{Code: "notFound", Message: "record(s) not found"}
+	ErrParams       = ErrorType{Code: "paramsError", Message: "invalid or incomplete params"}
+	ErrConnect      = ErrorType{Code: "connectError", Message: "database connection error"}
+	ErrUnAuthorized = ErrorType{Code: "unAuthorized", Message: "unauthorized action"}
+	ErrTokenExpired = ErrorType{Code: "tokenExpired", Message: "access-key expired"}
+	ErrExists       = ErrorType{Code: "exists", Message: "record already exists"}
+	ErrInsert       = ErrorType{Code: "insertError", Message: "insert error"}
+	ErrUpdate       = ErrorType{Code: "updateError", Message: "update error"}
+	ErrDelete       = ErrorType{Code: "deleteError", Message: "delete error"}
+	ErrRemove       = ErrorType{Code: "removeError", Message: "remove error"}
+	ErrRead         = ErrorType{Code: "readError", Message: "read error"}
+	ErrSave         = ErrorType{Code: "saveError", Message: "save error"}
+	ErrLog          = ErrorType{Code: "logError", Message: "audit-log error"}
+	ErrUnknown      = ErrorType{Code: "unknown", Message: "unknown error"}
+)
+
+// connection-error message fragments, for the drivers' errors without a typed connection-error
+var connectErrorMessages = []string{
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"bad connection",
+	"conn closed",
+	"connection closed",
+	"failed to connect",
+	"no connection",
+	"server closed the connection",
+	"database is closed",
+	"i/o timeout",
+}
+
+// NewError constructor returns a new crud-error, with the code, message and the wrapped cause
+func NewError(code string, message string, cause error) ErrorType {
+	return ErrorType{
+		Code:    code,
+		Message: message,
+		Cause:   cause,
+	}
+}
+
+// IsConnectError returns true, if the error is a (lost/refused) database connection-error
+func IsConnectError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if pgconn.Timeout(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	errMsg := strings.ToLower(err.Error())
+	for _, msg := range connectErrorMessages {
+		if strings.Contains(errMsg, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// DbError returns the crud-error for the database/gorm error: exists / paramsError (constraint-errors),
+// notFound (gorm.ErrRecordNotFound), connectError (connection-errors) or the code (e.g. readError), for the other errors
+func DbError(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	var crudErr ErrorType
+	if errors.As(err, &crudErr) {
+		return crudErr
+	}
+	if constraintErr, ok := TranslateDbError(err); ok {
+		constraintCode := "paramsError"
+		if constraintErr.Constraint == UniqueConstraint {
+			constraintCode = "exists"
+		}
+		return ErrorType{
+			Code:    constraintCode,
+			Message: constraintErr.Message,
+			Value:   []ConstraintErrorType{constraintErr},
+			Cause:   err,
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NewError("notFound", err.Error(), err)
+	}
+	if IsConnectError(err) {
+		return NewError("connectError", err.Error(), err)
+	}
+	return NewError(code, err.Error(), err)
+}
+
+// ResponseToError returns the crud-error for the (non-success) response, or nil for the success response
+func ResponseToError(res mcresponse.ResponseMessage) error {
+	if res.Code == "success" {
+		return nil
+	}
+	return ErrorType{
+		Code:    res.Code,
+		Message: res.Message,
+		Value:   res.Value,
+	}
+}
+
+// ErrorToResponse returns the response (for the API layer) for the crud-error, database/gorm error or other error
+func ErrorToResponse(err error) mcresponse.ResponseMessage {
+	if err == nil {
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Task completed successfully",
+			Value:   nil,
+		})
+	}
+	var crudErr ErrorType
+	if !errors.As(DbError(err, "unknown"), &crudErr) {
+		crudErr = NewError("unknown", err.Error(), err)
+	}
+	return mcresponse.GetResMessage(crudErr.Code, mcresponse.ResponseMessageOptions{
+		Message: crudErr.Message,
+		Value:   crudErr.Value,
+	})
+}
+
+// ErrorResponse method returns the response (ErrorToResponse) of the crud-error, and records the crud-error (with the
+// wrapped cause) of the crud-task, for the Go-native crud methods
+func (crud Crud) ErrorResponse(err error) mcresponse.ResponseMessage {
+	crudErr := DbError(err, "unknown")
+	crud.recordTaskError(crudErr)
+	return ErrorToResponse(crudErr)
+}
+
+// recordTaskError method records the crud-error of the crud-task, if run by a Go-native crud method
+func (crud Crud) recordTaskError(err error) {
+	if crud.taskErr != nil && err != nil {
+		*crud.taskErr = err
+	}
+}
+
+// taskResult method runs the crud-task, and returns the task-response and the recorded crud-error (DbError, with the
+// wrapped cause) of the response, or the crud-error of the (non-success) response
+func (crud *Crud) taskResult(task func() mcresponse.ResponseMessage) (mcresponse.ResponseMessage, error) {
+	var taskErr error
+	crud.taskErr = &taskErr
+	defer func() {
+		crud.taskErr = nil
+	}()
+	res := task()
+	if res.Code == "success" {
+		return res, nil
+	}
+	var crudErr ErrorType
+	if errors.As(taskErr, &crudErr) && crudErr.Code == res.Code {
+		return res, taskErr
+	}
+	return res, ResponseToError(res)
+}
+
+// GetRecordResult method is the Go-native GetRecord, returning the get-result or the crud-error
+func (crud *Crud) GetRecordResult(modelRef interface{}) (GetResultType, error) {
+	res, err := crud.taskResult(func() mcresponse.ResponseMessage {
+		return crud.GetRecord(modelRef)
+	})
+	if err != nil {
+		return GetResultType{}, err
+	}
+	getResult, ok := res.Value.(GetResultType)
+	if !ok {
+		return GetResultType{}, NewError("unknown", fmt.Sprintf("unexpected get-result type: %T", res.Value), nil)
+	}
+	return getResult, nil
+}
+
+// SaveRecordResult method is the Go-native SaveRecord, returning the crud-result or the crud-error
+func (crud *Crud) SaveRecordResult(modelRef interface{}, recs interface{}, batch int) (CrudResultType, error) {
+	return crudResult(crud.taskResult(func() mcresponse.ResponseMessage {
+		return crud.SaveRecord(modelRef, recs, batch)
+	}))
+}
+
+// DeleteRecordResult method is the Go-native DeleteRecord, returning the crud-result or the crud-error
+func (crud *Crud) DeleteRecordResult(modelRef interface{}) (CrudResultType, error) {
+	return crudResult(crud.taskResult(func() mcresponse.ResponseMessage {
+		return crud.DeleteRecord(modelRef)
+	}))
+}
+
+// crudResult returns the crud-result or the crud-error, for the crud-response
+func crudResult(res mcresponse.ResponseMessage, err error) (CrudResultType, error) {
+	if err != nil {
+		return CrudResultType{}, err
+	}
+	crudRes, ok := res.Value.(CrudResultType)
+	if !ok {
+		return CrudResultType{}, NewError("unknown", fmt.Sprintf("unexpected crud-result type: %T", res.Value), nil)
+	}
+	return crudRes, nil
+}
@@ -0,0 +1,89 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: typed crud-errors test cases
+
+package mcgorm
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCrudErrors(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should match the crud-error by code, and unwrap the cause:",
+		TestFunc: func() {
+			cause := errors.New("record 100 not found")
+			err := fmt.Errorf("get error: %w", NewError("notFound", "Record not found", cause))
+			mctest.AssertEquals(t, errors.Is(err, ErrNotFound), true, "errors.Is(ErrNotFound) should be: true")
+			mctest.AssertEquals(t, errors.Is(err, ErrConnect), false, "errors.Is(ErrConnect) should be: false")
+			mctest.AssertEquals(t, errors.Is(err, cause), true, "errors.Is(cause) should be: true")
+			var crudErr ErrorType
+			mctest.AssertEquals(t, errors.As(err, &crudErr), true, "errors.As(ErrorType) should be: true")
+			mctest.AssertEquals(t, crudErr.Code, "notFound", "error-code should be: notFound")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should distinguish the not-found, connection and constraint errors:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, errors.Is(DbError(gorm.ErrRecordNotFound, "readError"), ErrNotFound), true, "gorm not-found should be: notFound")
+			mctest.AssertEquals(t, errors.Is(DbError(driver.ErrBadConn, "readError"), ErrConnect), true, "bad-connection should be: connectError")
+			mctest.AssertEquals(t, errors.Is(DbError(errors.New("failed to connect to `host=localhost user=postgres`"), "readError"), ErrConnect), true, "pg connect-error should be: connectError")
+			mctest.AssertEquals(t, errors.Is(DbError(errors.New("UNIQUE constraint failed: groups.name"), "insertError"), ErrExists), true, "unique-error should be: exists")
+			mctest.AssertEquals(t, errors.Is(DbError(errors.New("syntax error"), "readError"), ErrRead), true, "other error should be: readError")
+			mctest.AssertEquals(t, DbError(nil, "readError"), nil, "nil error should be: nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should map the crud-errors to and from the responses:",
+		TestFunc: func() {
+			res := ErrorToResponse(fmt.Errorf("read: %w", driver.ErrBadConn))
+			mctest.AssertEquals(t, res.Code, "connectError", "response-code should be: connectError")
+			res = ErrorToResponse(errors.New("unexpected"))
+			mctest.AssertEquals(t, res.Code, "unknown", "response-code should be: unknown")
+			err := ResponseToError(mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{}))
+			mctest.AssertEquals(t, errors.Is(err, ErrNotFound), true, "response-error should be: notFound")
+			mctest.AssertEquals(t, ResponseToError(mcresponse.ResponseMessage{Code: "success"}), nil, "success response-error should be: nil")
+			crud := Crud{}
+			res = crud.DbErrorMessage(errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"), "readError")
+			mctest.AssertEquals(t, res.Code, "connectError", "db-error response-code should be: connectError")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the notFound response and error, for the missing record-ids:",
+		TestFunc: func() {
+			crud := Crud{}
+			res := crud.NotFoundMessage([]string{"id-100", "id-200"})
+			mctest.AssertEquals(t, res.Code, "notFound", "response-code should be: notFound")
+			mctest.AssertEquals(t, len(res.Value.([]string)), 2, "missing-ids should be: 2")
+			mctest.AssertEquals(t, errors.Is(ResponseToError(res), ErrNotFound), true, "response-error should be: notFound")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the recorded crud-error, with the wrapped database-error cause:",
+		TestFunc: func() {
+			crud := &Crud{}
+			pgErr := &pgconn.PgError{Code: "23505", Detail: "Key (name)=(Abi) already exists."}
+			res, err := crud.taskResult(func() mcresponse.ResponseMessage {
+				return crud.DbErrorMessage(fmt.Errorf("insert: %w", pgErr), "insertError")
+			})
+			mctest.AssertEquals(t, res.Code, "exists", "response-code should be: exists")
+			mctest.AssertEquals(t, errors.Is(err, ErrExists), true, "task-error should be: exists")
+			var causeErr *pgconn.PgError
+			mctest.AssertEquals(t, errors.As(err, &causeErr), true, "task-error cause should be: pg-error")
+			_, err = crud.taskResult(func() mcresponse.ResponseMessage {
+				return crud.NotFoundMessage([]string{"id-100"})
+			})
+			mctest.AssertEquals(t, errors.Is(err, ErrNotFound), true, "response-error should be: notFound")
+			mctest.AssertEquals(t, crud.taskErr == nil, true, "task-error recorder should be: reset")
+		},
+	})
+
+	mctest.PostTestResult()
+}
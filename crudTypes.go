@@ -101,6 +101,23 @@ type CheckAccessType struct {
 	IsAdmin      bool              `json:"isAdmin" mcorm:"isAdmin"`
 	RoleServices []RoleServiceType `json:"roleServices" mcorm:"roleServices"`
 	TableId      string            `json:"tableId" mcorm:"tableId"`
+	TokenExpire  int64             `json:"tokenExpire" mcorm:"tokenExpire"` // access-token expiry (unix-ms)
+}
+
+// EffectivePermissionType describes the resolved (direct and inherited) permissions of a user, for a table/service
+type EffectivePermissionType struct {
+	UserId           string            `json:"userId"`
+	TableName        string            `json:"tableName"`
+	ServiceId        string            `json:"serviceId"`
+	IsAdmin          bool              `json:"isAdmin"`
+	RoleIds          []string          `json:"roleIds"`
+	InheritedRoleIds []string          `json:"inheritedRoleIds"`
+	RoleServices     []RoleServiceType `json:"roleServices"`
+	CanRead          bool              `json:"canRead"`
+	CanCreate        bool              `json:"canCreate"`
+	CanUpdate        bool              `json:"canUpdate"`
+	CanDelete        bool              `json:"canDelete"`
+	CanCrud          bool              `json:"canCrud"`
 }
 
 type CheckAccessParamsType struct {
@@ -132,22 +149,39 @@ type QueryParamItemType struct {
 }
 type QueryParamsType []QueryParamItemType
 
+// RequestMetaType describes the client-request information, recorded with the audit-log entries
+type RequestMetaType struct {
+	ClientIp      string `json:"clientIp"`
+	UserAgent     string `json:"userAgent"`
+	RequestId     string `json:"requestId"`
+	CorrelationId string `json:"correlationId"`
+}
+
+// IncludeParamType describes the association (path) to eager-load (preload), with the optional projected fields
+type IncludeParamType struct {
+	Path   string   `json:"path"`   // association path, by the field or json names, e.g. group, parent.group
+	Fields []string `json:"fields"` // projected association fields (column, field or json names), all if empty
+}
+
 // CrudParamsType is the struct type for receiving, composing and passing CRUD inputs
 type CrudParamsType struct {
-	AppDb         *pgxpool.Pool    `json:"-"`
-	GormDb        *gorm.DB         `json:"-"`
-	TableName     string           `json:"-"`
-	UserInfo      UserInfoType     `json:"userInfo"`
-	ActionParams  ActionParamsType `json:"actionParams"`
-	QueryParams   QueryParamType   `json:"queryParams"`
-	RecordIds     []string         `json:"recordIds"`
-	ProjectParams ProjectParamType `json:"projectParams"`
-	SortParams    SortParamType    `json:"sortParams"`
-	Token         string           `json:"token"`
-	Skip          int              `json:"skip"`
-	Limit         int              `json:"limit"`
-	TaskType      string           `json:"-"`
-	TaskName      string           `json:"-"`
+	AppDb         *pgxpool.Pool      `json:"-"`
+	GormDb        *gorm.DB           `json:"-"`
+	TableName     string             `json:"-"`
+	UserInfo      UserInfoType       `json:"userInfo"`
+	ActionParams  ActionParamsType   `json:"actionParams"`
+	QueryParams   QueryParamType     `json:"queryParams"`
+	RecordIds     []string           `json:"recordIds"`
+	ProjectParams ProjectParamType   `json:"projectParams"`
+	IncludeParams []IncludeParamType `json:"includeParams"` // associations to eager-load, for the get/read tasks
+	SortParams    SortParamType      `json:"sortParams"`
+	Token         string             `json:"token"`
+	Skip          int                `json:"skip"`
+	Limit         int                `json:"limit"`
+	TaskType      string             `json:"-"`
+	TaskName      string             `json:"-"`
+	AppParams     AppParamsType      `json:"appParams"`   // app/tenant-scope, in a multi-hosted apps environment
+	RequestMeta   RequestMetaType    `json:"requestMeta"` // request information, for the audit-log
 }
 
 type CrudOptionsType struct {
@@ -165,21 +199,40 @@ type CrudOptionsType struct {
 	AccessTable           string
 	VerifyTable           string
 	ProfileTable          string
+	AppTable              string
+	RoleInheritTable      string
+	RoleInheritance       bool
+	MaxRoleDepth          int
 	MaxQueryLimit         int
 	LogCrud               bool
 	LogCreate             bool
 	LogUpdate             bool
 	LogRead               bool
 	LogDelete             bool
+	LogDiffOnly           bool                       // store only the field-level changes, for update-log
+	AuditHashChain        bool                       // tamper-evident, hash-chained audit-log entries
+	AsyncAudit            *AsyncAuditLog             // asynchronous, batched audit-log writer (shared by the crud-instances)
+	AuditLogger           AuditLogger                // audit-log sink (e.g. file, syslog, fan-out), default: the AuditTable (LogParam)
+	HistoryTables         []string                   // tables with record versioning, in <table>_history
+	StrictNotFound        bool                       // notFound for the missing single-id get/update/delete, missing-ids for the multi-id tasks
+	Hooks                 *HookRegistry              // before/after crud-task hooks, by table and task
+	Outbox                bool                       // transactional outbox change-events, for the create/update/delete tasks
+	OutboxTable           string                     // default: outbox_events
+	IdType                string                     // client-side id-type of the new records: uuidV4 (default) | uuidV7 | ulid
+	TreeTables            map[string]TreeOptionsType // self-referencing (tree) tables options, by table name
 	LogLogin              bool
 	LogLogout             bool
 	UnAuthorizedMessage   string
 	RecExistMessage       string
 	CacheExpire           int
+	CacheAccess           bool
 	LoginTimeout          int
+	SlidingExpiry         bool
 	UsernameExistsMessage string
 	EmailExistsMessage    string
 	MsgFrom               string
+	VerifyTimeout         int
+	Mailer                Mailer
 }
 
 type MessageObject map[string]string
@@ -194,8 +247,10 @@ type OkResponse struct {
 
 // ErrorType provides the structure for error reporting
 type ErrorType struct {
-	Code    string
+	Code    string // mcresponse code, e.g. notFound, connectError, paramsError
 	Message string
+	Value   interface{} // error details, e.g. []ConstraintErrorType
+	Cause   error       // wrapped/underlying error
 }
 
 type SaveError ErrorType
@@ -213,9 +268,28 @@ type DeleteQueryError ErrorType
 
 // sample Error() implementation
 func (err ErrorType) Error() string {
+	if err.Cause != nil {
+		return fmt.Sprintf("Error-code: %v | Error-message: %v | Cause: %v", err.Code, err.Message, err.Cause.Error())
+	}
 	return fmt.Sprintf("Error-code: %v | Error-message: %v", err.Code, err.Message)
 }
 
+// Unwrap returns the wrapped/underlying error, for errors.Is / errors.As
+func (err ErrorType) Unwrap() error {
+	return err.Cause
+}
+
+// Is reports the error as matching the target ErrorType (e.g. ErrNotFound) by code
+func (err ErrorType) Is(target error) bool {
+	switch targetErr := target.(type) {
+	case ErrorType:
+		return targetErr.Code != "" && targetErr.Code == err.Code
+	case *ErrorType:
+		return targetErr != nil && targetErr.Code != "" && targetErr.Code == err.Code
+	}
+	return false
+}
+
 type LogRecordsType struct {
 	TableFields  []string       `json:"table_fields"`
 	TableRecords []interface{}  `json:"table_records"`
@@ -227,9 +301,11 @@ type CrudResultType struct {
 	QueryParam   QueryParamType             `json:"queryParam"`
 	RecordIds    []string                   `json:"recordIds"`
 	RecordCount  int                        `json:"recordCount"`
+	MissingIds   []string                   `json:"missingIds"`
 	TableRecords []interface{}              `json:"tableRecords"`
 	TaskType     string                     `json:"taskType"`
 	LogRes       mcresponse.ResponseMessage `json:"logRes"`
+	HistoryRes   mcresponse.ResponseMessage `json:"historyRes"`
 }
 
 type GetStatType struct {
@@ -242,10 +318,11 @@ type GetStatType struct {
 }
 
 type GetResultType struct {
-	Records  []interface{}              `json:"value"`
-	Stats    GetStatType                `json:"stats"`
-	TaskType string                     `json:"taskType"`
-	LogRes   mcresponse.ResponseMessage `json:"logRes"`
+	Records    []interface{}              `json:"value"`
+	Stats      GetStatType                `json:"stats"`
+	TaskType   string                     `json:"taskType"`
+	LogRes     mcresponse.ResponseMessage `json:"logRes"`
+	MissingIds []string                   `json:"missingIds"`
 }
 
 type SaveResultType struct {
@@ -257,7 +334,7 @@ type SaveResultType struct {
 }
 
 type BaseModelType struct {
-	ID        string         `json:"id" gorm:"primaryKey;default:uuid_generate_v4()" mcorm:"id"`
+	ID        string         `json:"id" gorm:"primaryKey" mcorm:"id"`
 	Language  string         `json:"language" gorm:"not null;default:en-US" mcorm:"language"`
 	Desc      string         `json:"desc" mcorm:"desc"`
 	AppId     string         `json:"appId" mcorm:"app_id"`                           // application-id in a multi-hosted apps environment (e.g. cloud-env)
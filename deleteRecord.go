@@ -10,27 +10,50 @@ import (
 )
 
 func (crud Crud) DeleteById(modelRef interface{}, id string) mcresponse.ResponseMessage {
+	// strict not-found mode: notFound response for the missing record
+	if crud.StrictNotFound {
+		missingIds, mErr := crud.MissingRecordIds([]string{id})
+		if mErr != nil {
+			return crud.DbErrorMessage(mErr, "readError")
+		}
+		if len(missingIds) > 0 {
+			return crud.NotFoundMessage(missingIds)
+		}
+	}
 	var getRes mcresponse.ResponseMessage
 	if crud.LogDelete {
 		// get current record
 		getRes = crud.GetById(modelRef, id)
 	}
+	// record snapshots, for the record history
+	var historySnapshots []map[string]interface{}
+	if crud.HistoryEnabled() {
+		snapshots, sErr := crud.RecordSnapshots([]string{id})
+		if sErr != nil {
+			return mcresponse.GetResMessage("readError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", sErr.Error()),
+					Value:   nil,
+				})
+		}
+		historySnapshots = snapshots
+	}
 	// perform crud-delete task (permanent delete with Unscoped)
-	result := crud.GormDb.Where("id = ?", id).Unscoped().Delete(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Where("id = ?", id).Unscoped().Delete(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("deleteError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "deleteError")
 	}
 	// LogDelete
 	var logRes mcresponse.ResponseMessage
 	var err error
 	if crud.LogDelete {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Delete, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: getRes.Value,
-			TableName:  crud.TableName,
+			LogRecords:  getRes.Value,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   []string{id},
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -40,11 +63,17 @@ func (crud Crud) DeleteById(modelRef interface{}, id string) mcresponse.Response
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.WriteHistory(CrudTasks().Delete, historySnapshots)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				RecordCount: int(result.RowsAffected),
 			},
 		})
@@ -58,27 +87,49 @@ func (crud Crud) DeleteByIds(modelRef interface{}) mcresponse.ResponseMessage {
 				Value:   nil,
 			})
 	}
+	// strict not-found mode: report the missing records
+	var missingIds []string
+	if crud.StrictNotFound {
+		var mErr error
+		missingIds, mErr = crud.MissingRecordIds(crud.RecordIds)
+		if mErr != nil {
+			return crud.DbErrorMessage(mErr, "readError")
+		}
+	}
 	var getRes mcresponse.ResponseMessage
 	if crud.LogDelete {
 		// get current records
 		getRes = crud.GetByIds(modelRef)
 	}
+	// record snapshots, for the record history
+	var historySnapshots []map[string]interface{}
+	if crud.HistoryEnabled() {
+		snapshots, sErr := crud.RecordSnapshots(crud.RecordIds)
+		if sErr != nil {
+			return mcresponse.GetResMessage("readError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", sErr.Error()),
+					Value:   nil,
+				})
+		}
+		historySnapshots = snapshots
+	}
 	// perform crud-delete task
-	result := crud.GormDb.Where("id in ?", crud.RecordIds).Unscoped().Delete(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Where("id in ?", crud.RecordIds).Unscoped().Delete(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("deleteError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "deleteError")
 	}
 	// LogDelete
 	var logRes mcresponse.ResponseMessage
 	var err error
 	if crud.LogDelete {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Delete, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: getRes.Value,
-			TableName:  crud.TableName,
+			LogRecords:  getRes.Value,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   crud.RecordIds,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -88,12 +139,19 @@ func (crud Crud) DeleteByIds(modelRef interface{}) mcresponse.ResponseMessage {
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.WriteHistory(CrudTasks().Delete, historySnapshots)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				RecordCount: int(result.RowsAffected),
+				MissingIds:  missingIds,
 			},
 		})
 }
@@ -140,22 +198,43 @@ func (crud Crud) DeleteByParam(modelRef interface{}) mcresponse.ResponseMessage
 		}
 	}
 
+	// record snapshots (by the matching record-ids), for the record history
+	var historySnapshots []map[string]interface{}
+	if crud.HistoryEnabled() {
+		var historyIds []string
+		if idRes := crud.GormDb.Scopes(crud.AppScope).Model(&modelRef).Where(qString, qValues...).Pluck("id", &historyIds); idRes.Error != nil {
+			return mcresponse.GetResMessage("readError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", idRes.Error.Error()),
+					Value:   nil,
+				})
+		}
+		snapshots, sErr := crud.RecordSnapshots(historyIds)
+		if sErr != nil {
+			return mcresponse.GetResMessage("readError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", sErr.Error()),
+					Value:   nil,
+				})
+		}
+		historySnapshots = snapshots
+	}
 	// perform crud-delete task
-	result := crud.GormDb.Where(qString, qValues...).Unscoped().Delete(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Where(qString, qValues...).Unscoped().Delete(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("deleteError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "deleteError")
 	}
 	// LogDelete
 	var logRes mcresponse.ResponseMessage
 	var err error
 	if crud.LogDelete {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Delete, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: getRes.Value,
-			TableName:  crud.TableName,
+			LogRecords:  getRes.Value,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   RecordIdsFrom(getRes.Value),
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -165,11 +244,17 @@ func (crud Crud) DeleteByParam(modelRef interface{}) mcresponse.ResponseMessage
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.WriteHistory(CrudTasks().Delete, historySnapshots)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				RecordCount: int(result.RowsAffected),
 			},
 		})
@@ -11,15 +11,11 @@ import (
 )
 
 func (crud Crud) GetById(modelRef interface{}, id string) mcresponse.ResponseMessage {
-	// perform get-query
+	// perform get-query, without the limit/skip paging of the (single) record-by-id
 	//var result *gorm.DB
-	result := crud.GormDb.Limit(crud.Limit).Offset(crud.Skip).Where("id = ?", id).Find(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Where("id = ?", id).Find(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("readError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "readError")
 	}
 	// rows
 	var records []interface{}
@@ -59,14 +55,26 @@ func (crud Crud) GetById(modelRef interface{}, id string) mcresponse.ResponseMes
 		}
 		records = append(records, gValue)
 	}
+	// eager-load the included associations
+	if records, err = crud.IncludeRecords(modelRef, records); err != nil {
+		return crud.ErrorResponse(DbError(err, "readError"))
+	}
+	// strict not-found mode: notFound response for the missing record
+	if crud.StrictNotFound && len(records) < 1 {
+		return crud.NotFoundMessage([]string{id})
+	}
 	var totalRecordsCount int64
-	var _ = crud.GormDb.Find(modelRef).Count(&totalRecordsCount)
+	var _ = crud.GormDb.Scopes(crud.AppScope).Find(modelRef).Count(&totalRecordsCount)
 	// logRead
 	var logRes mcresponse.ResponseMessage
 	if crud.LogRead {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Read, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: []string{id},
-			TableName:  crud.TableName,
+			LogRecords:  []string{id},
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   []string{id},
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -100,14 +108,19 @@ func (crud Crud) GetByIds(modelRef interface{}) mcresponse.ResponseMessage {
 				Value:   nil,
 			})
 	}
+	// strict not-found mode: report the missing records
+	var missingIds []string
+	if crud.StrictNotFound {
+		var mErr error
+		missingIds, mErr = crud.MissingRecordIds(crud.RecordIds)
+		if mErr != nil {
+			return crud.DbErrorMessage(mErr, "readError")
+		}
+	}
 	// perform get-query
-	result := crud.GormDb.Limit(crud.Limit).Offset(crud.Skip).Where("id in ?", crud.RecordIds).Find(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Limit(crud.Limit).Offset(crud.Skip).Where("id in ?", crud.RecordIds).Find(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("readError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "readError")
 	}
 	// rows
 	var records []interface{}
@@ -147,14 +160,22 @@ func (crud Crud) GetByIds(modelRef interface{}) mcresponse.ResponseMessage {
 		}
 		records = append(records, gValue)
 	}
+	// eager-load the included associations
+	if records, err = crud.IncludeRecords(modelRef, records); err != nil {
+		return crud.ErrorResponse(DbError(err, "readError"))
+	}
 	var totalRecordsCount int64
-	var _ = crud.GormDb.Find(modelRef).Count(&totalRecordsCount)
+	var _ = crud.GormDb.Scopes(crud.AppScope).Find(modelRef).Count(&totalRecordsCount)
 	// logRead
 	var logRes mcresponse.ResponseMessage
 	if crud.LogRead {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Read, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: crud.RecordIds,
-			TableName:  crud.TableName,
+			LogRecords:  crud.RecordIds,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   crud.RecordIds,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -175,7 +196,8 @@ func (crud Crud) GetByIds(modelRef interface{}) mcresponse.ResponseMessage {
 					RecordsCount:      int(result.RowsAffected),
 					TotalRecordsCount: int(totalRecordsCount),
 				},
-				LogRes: logRes,
+				LogRes:     logRes,
+				MissingIds: missingIds,
 			},
 		})
 }
@@ -218,14 +240,9 @@ func (crud Crud) GetByParam(modelRef interface{}) mcresponse.ResponseMessage {
 	}
 
 	// perform get-query
-	result := crud.GormDb.Limit(crud.Limit).Offset(crud.Skip).Where(qString, qValues...).Find(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Limit(crud.Limit).Offset(crud.Skip).Where(qString, qValues...).Find(&modelRef)
 	if result.Error != nil {
-		errMsg := fmt.Sprintf("%v", result.Error.Error())
-		return mcresponse.GetResMessage("readError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "readError")
 	}
 	// rows
 	var records []interface{}
@@ -264,14 +281,22 @@ func (crud Crud) GetByParam(modelRef interface{}) mcresponse.ResponseMessage {
 		}
 		records = append(records, gValue)
 	}
+	// eager-load the included associations
+	if records, err = crud.IncludeRecords(modelRef, records); err != nil {
+		return crud.ErrorResponse(DbError(err, "readError"))
+	}
 	var totalRecordsCount int64
-	var _ = crud.GormDb.Find(modelRef).Count(&totalRecordsCount)
+	var _ = crud.GormDb.Scopes(crud.AppScope).Find(modelRef).Count(&totalRecordsCount)
 	// logRead
 	var logRes mcresponse.ResponseMessage
 	if crud.LogRead {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Read, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: crud.QueryParams,
-			TableName:  crud.TableName,
+			LogRecords:  crud.QueryParams,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   RecordIdsFrom(records),
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -299,13 +324,9 @@ func (crud Crud) GetByParam(modelRef interface{}) mcresponse.ResponseMessage {
 
 func (crud Crud) GetAll(modelRef interface{}) mcresponse.ResponseMessage {
 	// perform get-query
-	result := crud.GormDb.Limit(crud.Limit).Offset(crud.Skip).Find(&modelRef)
+	result := crud.GormDb.Scopes(crud.AppScope).Limit(crud.Limit).Offset(crud.Skip).Find(&modelRef)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("readError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "readError")
 	}
 	// rows
 	var records []interface{}
@@ -344,14 +365,21 @@ func (crud Crud) GetAll(modelRef interface{}) mcresponse.ResponseMessage {
 		}
 		records = append(records, gValue)
 	}
+	// eager-load the included associations
+	if records, err = crud.IncludeRecords(modelRef, records); err != nil {
+		return crud.ErrorResponse(DbError(err, "readError"))
+	}
 	var totalRecordsCount int64
-	var _ = crud.GormDb.Find(modelRef).Count(&totalRecordsCount)
+	var _ = crud.GormDb.Scopes(crud.AppScope).Find(modelRef).Count(&totalRecordsCount)
 	// logRead
 	var logRes mcresponse.ResponseMessage
 	if crud.LogRead {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Read, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: map[string]interface{}{"getType": "All Records"},
-			TableName:  crud.TableName,
+			LogRecords:  map[string]interface{}{"getType": "All Records"},
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -5,13 +5,15 @@ go 1.16
 require (
 	github.com/abbeymart/mccache v0.3.3 // indirect
 	github.com/abbeymart/mcdb v0.3.1 // indirect
-	github.com/abbeymart/mcresponse v0.5.0 // indirect
-	github.com/abbeymart/mctest v0.5.4 // indirect
-	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d // indirect
-	github.com/jackc/pgx/v4 v4.13.0 // indirect
+	github.com/abbeymart/mcresponse v0.5.0
+	github.com/abbeymart/mctest v0.5.4
+	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d
+	github.com/jackc/pgconn v1.10.0
+	github.com/jackc/pgx/v4 v4.13.0
 	github.com/mattn/go-sqlite3 v1.14.8 // indirect
+	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
 	gorm.io/driver/mysql v1.1.1 // indirect
-	gorm.io/driver/postgres v1.1.0 // indirect
+	gorm.io/driver/postgres v1.1.0
 	gorm.io/driver/sqlite v1.1.4 // indirect
-	gorm.io/gorm v1.21.12 // indirect
+	gorm.io/gorm v1.21.12
 )
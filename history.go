@@ -0,0 +1,393 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - record versioning (<table>_history) and point-in-time reconstruction
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"reflect"
+	"time"
+)
+
+// RecordHistory describe the data-model for the record versions (snapshots), in the <table>_history table
+type RecordHistory struct {
+	ID        string       `json:"id" gorm:"primaryKey" mcorm:"id"`
+	RecordId  string       `json:"recordId" mcorm:"record_id"`
+	Version   int          `json:"version" mcorm:"version"`
+	Operation string       `json:"operation" mcorm:"operation"` // create | update | delete | revert
+	Record    JsonDataType `json:"record" mcorm:"record"`       // full record snapshot (table-column keys)
+	ValidFrom time.Time    `json:"validFrom" mcorm:"valid_from"`
+	ValidTo   *time.Time   `json:"validTo" mcorm:"valid_to"` // nil for the current version
+	ChangedBy string       `json:"changedBy" mcorm:"changed_by"`
+	AppId     string       `json:"appId" mcorm:"app_id"`
+}
+
+// IdType method returns the time-ordered id-type of the record versions
+func (RecordHistory) IdType() string {
+	return UuidV7Id
+}
+
+// HistoryIndexFields are the indexed history-table fields/columns
+var HistoryIndexFields = []string{"record_id", "valid_from"}
+
+// HistoryWriteRetries is the maximum number of the version-writes of a record, on the concurrent version conflicts
+// (unique record_id and version)
+var HistoryWriteRetries = 3
+
+// RevertLog is the log/operation type for the reverted record version
+const RevertLog = "revert"
+
+// HistoryTable returns the history-table name for the crud-table
+func (crud Crud) HistoryTable() string {
+	return crud.TableName + "_history"
+}
+
+// HistoryEnabled returns true, if the history mode is enabled for the crud-table
+func (crud Crud) HistoryEnabled() bool {
+	return ArrayStringContains(crud.HistoryTables, crud.TableName)
+}
+
+// SetupHistoryTable method creates/migrates the history-table and the indexes, for the crud-table
+func (crud Crud) SetupHistoryTable() error {
+	historyTable := crud.HistoryTable()
+	if err := crud.GormDb.Table(historyTable).AutoMigrate(&RecordHistory{}); err != nil {
+		return errors.New(fmt.Sprintf("error migrating history-table %v: %v", historyTable, err.Error()))
+	}
+	for _, field := range HistoryIndexFields {
+		indexName := fmt.Sprintf("idx_%v_%v", historyTable, field)
+		if crud.GormDb.Migrator().HasIndex(historyTable, indexName) {
+			continue
+		}
+		indexScript := fmt.Sprintf("CREATE INDEX %v ON %v (%v)", indexName, historyTable, field)
+		if err := crud.GormDb.Exec(indexScript).Error; err != nil {
+			return errors.New(fmt.Sprintf("error creating history-table index %v: %v", indexName, err.Error()))
+		}
+	}
+	// unique record-version, for the concurrent version-writes
+	indexName := fmt.Sprintf("uidx_%v_record_id_version", historyTable)
+	if !crud.GormDb.Migrator().HasIndex(historyTable, indexName) {
+		indexScript := fmt.Sprintf("CREATE UNIQUE INDEX %v ON %v (record_id, version)", indexName, historyTable)
+		if err := crud.GormDb.Exec(indexScript).Error; err != nil {
+			return errors.New(fmt.Sprintf("error creating history-table index %v: %v", indexName, err.Error()))
+		}
+	}
+	return nil
+}
+
+// RecordSnapshots method returns the current table-records (table-column keys), by the record-ids
+func (crud Crud) RecordSnapshots(ids []string) ([]map[string]interface{}, error) {
+	var snapshots []map[string]interface{}
+	if len(ids) < 1 {
+		return snapshots, nil
+	}
+	result := crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).Where("id IN ?", ids).Find(&snapshots)
+	if result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("error reading record snapshots: %v", result.Error.Error()))
+	}
+	return snapshots, nil
+}
+
+// WriteHistory method closes the current versions and inserts the new versions of the records (snapshots).
+// The delete-version is valid for no time (validFrom = validTo), i.e. the record does not exist after the delete.
+// The version-write of a record is retried (HistoryWriteRetries), on the concurrent version conflict.
+func (crud Crud) WriteHistory(operation string, snapshots []map[string]interface{}) mcresponse.ResponseMessage {
+	now := time.Now()
+	err := crud.GormDb.Transaction(func(tx *gorm.DB) error {
+		for _, snapshot := range snapshots {
+			var err error
+			for attempt := 0; attempt < HistoryWriteRetries; attempt++ {
+				// nested transaction (savepoint), rolled back on the version conflict
+				err = tx.Transaction(func(versionTx *gorm.DB) error {
+					return crud.writeVersion(versionTx, operation, snapshot, now)
+				})
+				if constraintErr, ok := TranslateDbError(err); !ok || constraintErr.Constraint != UniqueConstraint {
+					break
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("History-record error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "History-record(s) saved successfully",
+		Value:   len(snapshots),
+	})
+}
+
+// writeVersion method closes the current version and inserts the next version of the record (snapshot)
+func (crud Crud) writeVersion(tx *gorm.DB, operation string, snapshot map[string]interface{}, now time.Time) error {
+	historyTable := crud.HistoryTable()
+	recordId := fmt.Sprintf("%v", snapshot["id"])
+	var lastVersion int
+	if err := tx.Table(historyTable).Where("record_id = ?", recordId).Select("COALESCE(MAX(version), 0)").Row().Scan(&lastVersion); err != nil {
+		return err
+	}
+	if err := tx.Table(historyTable).Where("record_id = ? AND valid_to IS NULL", recordId).Update("valid_to", now).Error; err != nil {
+		return err
+	}
+	historyId, err := NewId(RecordHistory{}.IdType())
+	if err != nil {
+		return err
+	}
+	history := RecordHistory{
+		ID:        historyId,
+		RecordId:  recordId,
+		Version:   lastVersion + 1,
+		Operation: operation,
+		Record:    JsonDataType{Data: snapshot},
+		ValidFrom: now,
+		ChangedBy: crud.UserInfo.UserId,
+		AppId:     crud.AppParams.AppId,
+	}
+	if operation == CrudTasks().Delete {
+		history.ValidTo = &now
+	}
+	return tx.Table(historyTable).Create(&history).Error
+}
+
+// SaveHistory method writes the current versions of the created/updated records, by the record-ids
+func (crud Crud) SaveHistory(operation string, ids []string) mcresponse.ResponseMessage {
+	snapshots, err := crud.RecordSnapshots(ids)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("History-record error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return crud.WriteHistory(operation, snapshots)
+}
+
+// GetRecordVersions method returns all the versions of the record, in version order
+func (crud Crud) GetRecordVersions(id string) mcresponse.ResponseMessage {
+	var versions []RecordHistory
+	result := crud.GormDb.Table(crud.HistoryTable()).Scopes(crud.AppScope).Where("record_id = ?", id).Order("version asc").Find(&versions)
+	if result.Error != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", result.Error.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Task completed successfully",
+		Value:   versions,
+	})
+}
+
+// GetRecordAsOf method returns the record version (RecordHistory) valid at the asOf time
+func (crud Crud) GetRecordAsOf(id string, asOf time.Time) mcresponse.ResponseMessage {
+	var versions []RecordHistory
+	result := crud.GormDb.Table(crud.HistoryTable()).Scopes(crud.AppScope).
+		Where("record_id = ? AND valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", id, asOf, asOf).
+		Order("version desc").Limit(1).Find(&versions)
+	if result.Error != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", result.Error.Error()),
+			Value:   nil,
+		})
+	}
+	if len(versions) < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Record %v did not exist at %v", id, asOf.Format(time.RFC3339)),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Task completed successfully",
+		Value:   versions[0],
+	})
+}
+
+// RevertRecord method restores the record to the specified version (re-creating a deleted record), with the
+// app/tenant access and update task-permission checks and the update hooks (and outbox change-event) of the table.
+// The revert-record is validated and checked (constraints and tree-cycles) as the update-record, and the tree-paths
+// are recomputed. The revert is recorded as a new (revert) version, and audit-logged as an update, if LogUpdate is set.
+func (crud Crud) RevertRecord(modelRef interface{}, id string, version int) mcresponse.ResponseMessage {
+	if !crud.HistoryEnabled() {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("History mode not enabled for table %v", crud.TableName),
+			Value:   nil,
+		})
+	}
+	if accessRes := crud.taskAccess(CrudTasks().Update); accessRes.Code != "success" {
+		return accessRes
+	}
+	revertRec, versionRes := crud.versionRecord(modelRef, id, version)
+	if versionRes.Code != "success" {
+		return versionRes
+	}
+	revertCrud := crud
+	revertCrud.TaskType = CrudTasks().Update
+	revertCrud.RecordIds = []string{id}
+	revertCrud.QueryParams = nil
+	revertRecs := []interface{}{revertRec}
+	return revertCrud.RunWithHooks(CrudTasks().Update, modelRef, revertRecs, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+		// revert-record, as set by the before-hooks
+		if recs, ok := taskRecs.([]interface{}); ok && len(recs) == 1 {
+			if rec, ok := recs[0].(map[string]interface{}); ok {
+				revertRec = rec
+			}
+		}
+		return taskCrud.revertRecord(modelRef, id, version, revertRec)
+	})
+}
+
+// versionRecord method returns the record (model/table-columns and id) of the specified (non-delete) version
+func (crud Crud) versionRecord(modelRef interface{}, id string, version int) (map[string]interface{}, mcresponse.ResponseMessage) {
+	var versions []RecordHistory
+	result := crud.GormDb.Table(crud.HistoryTable()).Scopes(crud.AppScope).Where("record_id = ? AND version = ?", id, version).Find(&versions)
+	if result.Error != nil {
+		return nil, mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", result.Error.Error()),
+			Value:   nil,
+		})
+	}
+	if len(versions) < 1 || versions[0].Operation == CrudTasks().Delete {
+		return nil, mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Version %v of record %v not found, or is a delete-version", version, id),
+			Value:   nil,
+		})
+	}
+	snapshot, ok := versions[0].Record.Data.(map[string]interface{})
+	if !ok {
+		return nil, mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Invalid snapshot for version %v of record %v", version, id),
+			Value:   nil,
+		})
+	}
+	// restore the model/table-columns only
+	stmt := &gorm.Statement{DB: crud.GormDb}
+	if err := stmt.Parse(modelRef); err != nil {
+		return nil, mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", err.Error()),
+			Value:   nil,
+		})
+	}
+	versionRec := map[string]interface{}{"id": id}
+	for _, column := range stmt.Schema.DBNames {
+		if val, ok := snapshot[column]; ok && column != "id" {
+			versionRec[column] = val
+		}
+	}
+	return versionRec, mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Task completed successfully",
+		Value:   nil,
+	})
+}
+
+// revertRecord method updates (or re-creates) the record with the revert-record values, and records the revert-version
+func (crud *Crud) revertRecord(modelRef interface{}, id string, version int, revertRec map[string]interface{}) mcresponse.ResponseMessage {
+	currentRecs, err := crud.RecordSnapshots([]string{id})
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", err.Error()),
+			Value:   nil,
+		})
+	}
+	updateRec := map[string]interface{}{}
+	for column, val := range revertRec {
+		if column != "id" {
+			updateRec[column] = val
+		}
+	}
+	// validate and check the revert-record, as the update-record
+	rec, err := crud.modelRecord(modelRef, revertRec)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if validateRes := ValidateRecords(rec); !validateRes.Ok {
+		return GetParamsMessage(validateRes.Errors, "paramsError")
+	}
+	if constraintRes := crud.CheckConstraints(modelRef, rec, []string{id}); constraintRes.Code != "success" {
+		return constraintRes
+	}
+	var treeIds []string
+	if crud.TreeEnabled() {
+		nodeIds, treeRes := crud.CheckTreeUpdate(modelRef, rec, []string{id})
+		if treeRes.Code != "success" {
+			return treeRes
+		}
+		treeIds = nodeIds
+	}
+	var result *gorm.DB
+	if len(currentRecs) > 0 {
+		result = crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", id).Updates(updateRec)
+	} else {
+		updateRec["id"] = id
+		result = crud.GormDb.Table(crud.TableName).Create(updateRec)
+	}
+	if result.Error != nil {
+		return crud.DbErrorMessage(result.Error, "updateError")
+	}
+	// tree-table: recompute the materialized paths of the reverted node and its descendants
+	if err = crud.UpdateTreePaths(treeIds); err != nil {
+		return crud.DbErrorMessage(err, "updateError")
+	}
+	historyRes := crud.SaveHistory(RevertLog, []string{id})
+	// LogUpdate
+	var logRes mcresponse.ResponseMessage
+	if crud.LogUpdate {
+		logRes, err = crud.TransLog.AuditLog(CrudTasks().Update, crud.UserInfo.UserId, AuditLogOptionsType{
+			LogRecords:    currentRecs,
+			NewLogRecords: map[string]interface{}{"id": []string{id}, "version": version, "record": updateRec},
+			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			QueryParams:   crud.QueryParams,
+			RecordIds:     []string{id},
+			LogDiffs:      ComputeRecordDiffs(currentRecs, updateRec),
+			DiffOnly:      crud.LogDiffOnly,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+	return mcresponse.GetResMessage("success",
+		mcresponse.ResponseMessageOptions{
+			Message: "Task completed successfully",
+			Value: CrudResultType{
+				RecordIds:   []string{id},
+				RecordCount: int(result.RowsAffected),
+				LogRes:      logRes,
+				HistoryRes:  historyRes,
+				TaskType:    crud.TaskType,
+			},
+		})
+}
+
+// modelRecord method returns the model-record (pointer to the model-type struct) of the record (table-columns) values
+func (crud Crud) modelRecord(modelRef interface{}, record map[string]interface{}) (interface{}, error) {
+	stmt := &gorm.Statement{DB: crud.GormDb}
+	if err := stmt.Parse(modelRef); err != nil {
+		return nil, err
+	}
+	rec := reflect.New(stmt.Schema.ModelType)
+	for column, val := range record {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil || val == nil {
+			continue
+		}
+		if err := field.Set(rec.Elem(), val); err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid value for field %v: %v", column, err.Error()))
+		}
+	}
+	return rec.Interface(), nil
+}
@@ -0,0 +1,48 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: record versioning (history) test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestRecordHistory(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the revert, for the table without the history mode:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: GroupTable}, CrudOptionsType{})
+			mctest.AssertEquals(t, crud.HistoryEnabled(), false, "history-enabled should be: false")
+			res := crud.RevertRecord(&Group{}, "g1", 1)
+			mctest.AssertEquals(t, res.Code, "paramsError", "response-code should be: paramsError")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the history-table name:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: GroupTable}, CrudOptionsType{HistoryTables: []string{GroupTable}})
+			mctest.AssertEquals(t, crud.HistoryEnabled(), true, "history-enabled should be: true")
+			mctest.AssertEquals(t, crud.HistoryTable(), GroupTable+"_history", "history-table should be: <table>_history")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the model-record of the revert-record, for the pre-save checks:",
+		TestFunc: func() {
+			crud := Crud{}
+			crud.GormDb, _ = gorm.Open(nil, &gorm.Config{DryRun: true})
+			rec, err := crud.modelRecord(&Category{}, map[string]interface{}{"id": "c1", "name": "books", "priority": float64(2), "parent_id": "c0"})
+			mctest.AssertEquals(t, err, nil, "model-record error should be: nil")
+			category, ok := rec.(*Category)
+			mctest.AssertEquals(t, ok, true, "model-record type should be: *Category")
+			mctest.AssertEquals(t, category.ID, "c1", "model-record id should be: c1")
+			mctest.AssertEquals(t, category.Name, "books", "model-record name should be: books")
+			mctest.AssertEquals(t, category.Priority, uint(2), "model-record priority should be: 2")
+			mctest.AssertEquals(t, category.ParentId != nil && *category.ParentId == "c0", true, "model-record parentId should be: c0")
+		},
+	})
+
+	mctest.PostTestResult()
+}
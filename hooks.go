@@ -0,0 +1,213 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - before/after crud lifecycle hooks, by table and task
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"sync"
+)
+
+// hook types
+const (
+	BeforeCreateHook = "beforeCreate"
+	AfterCreateHook  = "afterCreate"
+	BeforeUpdateHook = "beforeUpdate"
+	AfterUpdateHook  = "afterUpdate"
+	BeforeDeleteHook = "beforeDelete"
+	AfterDeleteHook  = "afterDelete"
+	BeforeReadHook   = "beforeRead"
+	AfterReadHook    = "afterRead"
+)
+
+// HookContextType provides the crud-context for the hook-functions:
+// the before-hooks may mutate the Records (input), the after-hooks may enrich the Result (output)
+type HookContextType struct {
+	Crud      *Crud    // crud-instance, with the transaction (Tx) as GormDb
+	Tx        *gorm.DB // transaction of the crud-task
+	TableName string
+	HookType  string
+	TaskType  string
+	ModelRef  interface{}
+	Records   interface{}                 // save-records (create/update tasks)
+	Result    *mcresponse.ResponseMessage // task-response, for the after-hooks
+}
+
+// HookFunc is the hook-function; the returned error vetoes the crud-task and rolls back the transaction
+type HookFunc func(hookCtx *HookContextType) error
+
+// HookRegistry holds the registered hook-functions, by table and hook type
+type HookRegistry struct {
+	hooks map[string][]HookFunc // key: <tableName>:<hookType>
+	mutex sync.RWMutex
+}
+
+// before/after hook types, by crud-task
+var taskHookTypes = map[string][2]string{
+	CrudTasks().Create: {BeforeCreateHook, AfterCreateHook},
+	CrudTasks().Update: {BeforeUpdateHook, AfterUpdateHook},
+	CrudTasks().Delete: {BeforeDeleteHook, AfterDeleteHook},
+	CrudTasks().Read:   {BeforeReadHook, AfterReadHook},
+}
+
+// NewHookRegistry constructor returns a new (empty) hook-registry
+func NewHookRegistry() *HookRegistry {
+	result := &HookRegistry{}
+	result.hooks = map[string][]HookFunc{}
+	return result
+}
+
+// hookKey returns the registry-key for the table and hook type
+func hookKey(tableName string, hookType string) string {
+	return tableName + ":" + hookType
+}
+
+// Register method adds the hook-function for the table and hook type (e.g. BeforeCreateHook);
+// the hook-functions run in the registration order
+func (registry *HookRegistry) Register(tableName string, hookType string, hookFunc HookFunc) error {
+	validHookType := false
+	for _, hookTypes := range taskHookTypes {
+		if hookType == hookTypes[0] || hookType == hookTypes[1] {
+			validHookType = true
+			break
+		}
+	}
+	if !validHookType {
+		return errors.New(fmt.Sprintf("invalid hook type: %v", hookType))
+	}
+	if tableName == "" || hookFunc == nil {
+		return errors.New("tableName and hookFunc are required to register a hook")
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	key := hookKey(tableName, hookType)
+	registry.hooks[key] = append(registry.hooks[key], hookFunc)
+	return nil
+}
+
+// Hooks method returns the hook-functions for the table and hook type
+func (registry *HookRegistry) Hooks(tableName string, hookType string) []HookFunc {
+	if registry == nil {
+		return nil
+	}
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return append([]HookFunc{}, registry.hooks[hookKey(tableName, hookType)]...)
+}
+
+// HasTaskHooks method returns true, if any before/after hook is registered for the table and crud-task
+func (registry *HookRegistry) HasTaskHooks(tableName string, taskType string) bool {
+	hookTypes, ok := taskHookTypes[taskType]
+	if !ok {
+		return false
+	}
+	return len(registry.Hooks(tableName, hookTypes[0])) > 0 || len(registry.Hooks(tableName, hookTypes[1])) > 0
+}
+
+// RunHooks method runs the hook-functions for the table and hook type, and stops at the first error
+func (registry *HookRegistry) RunHooks(hookType string, hookCtx *HookContextType) error {
+	hookCtx.HookType = hookType
+	for _, hookFunc := range registry.Hooks(hookCtx.TableName, hookType) {
+		if err := hookFunc(hookCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunWithHooks method runs the crud-task with the before/after hooks of the table, and records the outbox
+// change-event (if enabled), in a transaction, with the record history (if enabled) of the crud-task.
+// The crud-task runs directly, if no hook is registered for the table and task, and the outbox and history are disabled.
+func (crud *Crud) RunWithHooks(taskType string, modelRef interface{}, recs interface{}, taskFunc func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	outboxEnabled := crud.OutboxEnabled(taskType)
+	historyEnabled := crud.HistoryEnabled() && taskType != CrudTasks().Read
+	if !outboxEnabled && !historyEnabled && !crud.Hooks.HasTaskHooks(crud.TableName, taskType) {
+		return taskFunc(crud, recs)
+	}
+	// set the new record-ids before the hooks and the task, for the hooks and the outbox change-event
+	if taskType == CrudTasks().Create && recs != nil {
+		idRecs, err := SetRecordIds(recs, crud.IdType)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("%v", err.Error()),
+				Value:   nil,
+			})
+		}
+		recs = idRecs
+	}
+	hookTypes := taskHookTypes[taskType]
+	var res mcresponse.ResponseMessage
+	err := crud.GormDb.Transaction(func(tx *gorm.DB) error {
+		txCrud := *crud
+		txCrud.GormDb = tx
+		hookCtx := &HookContextType{
+			Crud:      &txCrud,
+			Tx:        tx,
+			TableName: crud.TableName,
+			TaskType:  taskType,
+			ModelRef:  modelRef,
+			Records:   recs,
+		}
+		if err := crud.Hooks.RunHooks(hookTypes[0], hookCtx); err != nil {
+			return hookError(hookTypes[0], err)
+		}
+		// update/delete record-ids, before the task, for the outbox change-event
+		var recordIds []string
+		if outboxEnabled && taskType != CrudTasks().Create {
+			ids, err := txCrud.OutboxRecordIds()
+			if err != nil {
+				return DbError(err, "readError")
+			}
+			recordIds = ids
+		}
+		res = taskFunc(&txCrud, hookCtx.Records)
+		if res.Code != "success" {
+			return ResponseToError(res)
+		}
+		// record history, written in the task transaction
+		if result, ok := res.Value.(CrudResultType); ok && historyEnabled && result.HistoryRes.Code != "" && result.HistoryRes.Code != "success" {
+			res = result.HistoryRes
+			return ResponseToError(res)
+		}
+		hookCtx.Result = &res
+		if err := crud.Hooks.RunHooks(hookTypes[1], hookCtx); err != nil {
+			return hookError(hookTypes[1], err)
+		}
+		if outboxEnabled {
+			// record-ids of the task result (e.g. the moved subtree), or the update/delete or created records
+			if result, ok := res.Value.(CrudResultType); ok && len(result.RecordIds) > 0 {
+				recordIds = result.RecordIds
+			}
+			if len(recordIds) < 1 {
+				recordIds = RecordIdsFrom(hookCtx.Records)
+			}
+			return txCrud.WriteOutboxEvent(taskType, recordIds, hookCtx.Records)
+		}
+		return nil
+	})
+	if err != nil {
+		// crud-task error response
+		if res.Code != "" && res.Code != "success" {
+			return res
+		}
+		// hook (veto) or transaction error
+		return crud.ErrorResponse(err)
+	}
+	return res
+}
+
+// hookError returns the crud-error for the hook-error (veto), with the hook's crud-error code or paramsError
+func hookError(hookType string, err error) error {
+	code := "paramsError"
+	message := err.Error()
+	var hookErr ErrorType
+	if errors.As(err, &hookErr) {
+		code = hookErr.Code
+		message = hookErr.Message
+	}
+	return NewError(code, fmt.Sprintf("Hook (%v) error: %v", hookType, message), err)
+}
@@ -0,0 +1,96 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud lifecycle hooks test cases
+
+package mcgorm
+
+import (
+	"errors"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestHookRegistry(t *testing.T) {
+	registry := NewHookRegistry()
+	mctest.McTest(mctest.OptionValue{
+		Name: "should register the hooks, and reject the invalid hook type:",
+		TestFunc: func() {
+			err := registry.Register("categories", BeforeCreateHook, func(hookCtx *HookContextType) error {
+				recs := hookCtx.Records.([]map[string]interface{})
+				recs[0]["path"] = "/" + recs[0]["name"].(string)
+				return nil
+			})
+			mctest.AssertEquals(t, err, nil, "register error should be: nil")
+			err = registry.Register("categories", BeforeCreateHook, func(hookCtx *HookContextType) error {
+				recs := hookCtx.Records.([]map[string]interface{})
+				recs[0]["path"] = recs[0]["path"].(string) + "/"
+				return nil
+			})
+			mctest.AssertEquals(t, err, nil, "register error should be: nil")
+			err = registry.Register("categories", "beforeSave", func(hookCtx *HookContextType) error { return nil })
+			mctest.AssertEquals(t, err != nil, true, "invalid hook type error should be: not nil")
+			mctest.AssertEquals(t, registry.HasTaskHooks("categories", CrudTasks().Create), true, "create hooks should be: true")
+			mctest.AssertEquals(t, registry.HasTaskHooks("categories", CrudTasks().Delete), false, "delete hooks should be: false")
+			mctest.AssertEquals(t, registry.HasTaskHooks("groups", CrudTasks().Create), false, "groups create hooks should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should run the hooks in the registration order, mutating the records:",
+		TestFunc: func() {
+			recs := []map[string]interface{}{{"name": "books"}}
+			hookCtx := &HookContextType{TableName: "categories", Records: recs}
+			err := registry.RunHooks(BeforeCreateHook, hookCtx)
+			mctest.AssertEquals(t, err, nil, "run-hooks error should be: nil")
+			mctest.AssertEquals(t, recs[0]["path"], "/books/", "path should be: /books/")
+			mctest.AssertEquals(t, hookCtx.HookType, BeforeCreateHook, "hook type should be: beforeCreate")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should veto the crud-task with the hook's error code:",
+		TestFunc: func() {
+			err := hookError(BeforeDeleteHook, errors.New("category has items"))
+			mctest.AssertEquals(t, errors.Is(err, ErrParams), true, "plain hook-error should be: paramsError")
+			err = hookError(BeforeDeleteHook, NewError("unAuthorized", "not the owner", nil))
+			mctest.AssertEquals(t, errors.Is(err, ErrUnAuthorized), true, "crud hook-error should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should run the crud-task directly, without the registered hooks:",
+		TestFunc: func() {
+			crud := &Crud{}
+			crud.TableName = "groups"
+			crud.Hooks = registry
+			res := crud.RunWithHooks(CrudTasks().Create, nil, nil, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+				mctest.AssertEquals(t, taskCrud == crud, true, "task crud-instance should be: the crud-instance")
+				return mcresponse.ResponseMessage{Code: "success"}
+			})
+			mctest.AssertEquals(t, res.Code, "success", "response-code should be: success")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should deny the unauthorized caller, before the before-hooks:",
+		TestFunc: func() {
+			hookRuns := 0
+			deniedRegistry := NewHookRegistry()
+			for _, hookType := range []string{BeforeCreateHook, BeforeReadHook} {
+				_ = deniedRegistry.Register("groups", hookType, func(hookCtx *HookContextType) error {
+					hookRuns++
+					return nil
+				})
+			}
+			crud := &Crud{}
+			crud.TableName = "groups"
+			crud.TaskType = CrudTasks().Create
+			crud.AppParams = AppParamsType{AppId: "app-100"}
+			crud.Hooks = deniedRegistry
+			res := crud.SaveRecord(&Group{}, []interface{}{Group{Name: "services"}}, 0)
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "save response-code should be: unAuthorized")
+			res = crud.GetRecord(&Group{})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "get response-code should be: unAuthorized")
+			mctest.AssertEquals(t, hookRuns, 0, "before-hook runs should be: 0")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,164 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - client-side record-ids (UUIDv4, UUIDv7, ULID), by model or crud-option
+
+package mcgorm
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// id types
+const (
+	UuidV4Id = "uuidV4" // random
+	UuidV7Id = "uuidV7" // time-ordered (unix-ms) uuid
+	UlidId   = "ulid"   // time-ordered (unix-ms), 26-char Crockford base32
+)
+
+// IdTyper is implemented by the models selecting the model id-type, e.g. func (Audit) IdType() string { return UuidV7Id }
+type IdTyper interface {
+	IdType() string
+}
+
+// Crockford base32 alphabet, for the ULID encoding
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// NewId returns a new record-id of the id-type (default: UuidV4Id)
+func NewId(idType string) (string, error) {
+	switch idType {
+	case UuidV4Id, "":
+		return NewUuidV4()
+	case UuidV7Id:
+		return NewUuidV7()
+	case UlidId:
+		return NewUlid()
+	default:
+		return "", errors.New(fmt.Sprintf("unknown id-type: %v", idType))
+	}
+}
+
+// NewUuidV4 returns a new random (version 4) uuid
+func NewUuidV4() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[:]); err != nil {
+		return "", errors.New(fmt.Sprintf("error generating uuid: %v", err.Error()))
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant RFC4122
+	return formatUuid(uuid), nil
+}
+
+// NewUuidV7 returns a new time-ordered (version 7) uuid: 48-bit unix-ms timestamp and 74 random bits
+func NewUuidV7() (string, error) {
+	var uuid [16]byte
+	if _, err := rand.Read(uuid[6:]); err != nil {
+		return "", errors.New(fmt.Sprintf("error generating uuid: %v", err.Error()))
+	}
+	putUnixMs(uuid[:6], time.Now())
+	uuid[6] = (uuid[6] & 0x0f) | 0x70 // version 7
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // variant RFC4122
+	return formatUuid(uuid), nil
+}
+
+// NewUlid returns a new time-ordered ULID: 48-bit unix-ms timestamp and 80 random bits, Crockford base32 encoded
+func NewUlid() (string, error) {
+	var ulid [16]byte
+	if _, err := rand.Read(ulid[6:]); err != nil {
+		return "", errors.New(fmt.Sprintf("error generating ulid: %v", err.Error()))
+	}
+	putUnixMs(ulid[:6], time.Now())
+	// 128 bits => 26 x 5-bit characters (the first character holds the top 3 bits)
+	hi := binary.BigEndian.Uint64(ulid[:8])
+	lo := binary.BigEndian.Uint64(ulid[8:])
+	encoded := make([]byte, 26)
+	for i := 0; i < 26; i++ {
+		shift := uint(125 - 5*i)
+		var index uint64
+		switch {
+		case shift >= 64:
+			index = hi >> (shift - 64)
+		case shift+5 <= 64:
+			index = lo >> shift
+		default:
+			index = hi<<(64-shift) | lo>>shift
+		}
+		encoded[i] = crockfordAlphabet[index&0x1f]
+	}
+	return string(encoded), nil
+}
+
+// putUnixMs writes the 48-bit (big-endian) unix-ms timestamp into the 6-byte slice
+func putUnixMs(dst []byte, t time.Time) {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 5; i >= 0; i-- {
+		dst[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// formatUuid returns the canonical (8-4-4-4-12 hex) uuid string
+func formatUuid(uuid [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+}
+
+// ModelIdType returns the id-type of the record-model (IdTyper), or the defaultIdType
+func ModelIdType(rec interface{}, defaultIdType string) string {
+	if idTyper, ok := rec.(IdTyper); ok && idTyper.IdType() != "" {
+		return idTyper.IdType()
+	}
+	if recValue := reflect.ValueOf(rec); recValue.Kind() == reflect.Struct {
+		// pointer-receiver IdType method
+		recPtr := reflect.New(recValue.Type())
+		recPtr.Elem().Set(recValue)
+		if idTyper, ok := recPtr.Interface().(IdTyper); ok && idTyper.IdType() != "" {
+			return idTyper.IdType()
+		}
+	}
+	return defaultIdType
+}
+
+// SetRecordIds sets the new ids (by the model id-type or the defaultIdType) of the struct/*struct record,
+// or the slice of struct-records, with the empty (string) ID field, and returns the updated record(s).
+// The records without the (string) ID field are returned unchanged.
+func SetRecordIds(recs interface{}, defaultIdType string) (interface{}, error) {
+	if recs == nil {
+		return nil, errors.New("recs parameter is required")
+	}
+	v := reflect.ValueOf(recs)
+	if v.Kind() == reflect.Slice {
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if item.Kind() == reflect.Interface {
+				item = item.Elem()
+			}
+			if !item.IsValid() {
+				return nil, errors.New(fmt.Sprintf("recs[%v] parameter must be of type struct{}", i))
+			}
+			rec, err := SetRecordIds(item.Interface(), defaultIdType)
+			if err != nil {
+				return nil, err
+			}
+			result.Index(i).Set(reflect.ValueOf(rec))
+		}
+		return result.Interface(), nil
+	}
+	recValue := reflect.Indirect(v)
+	if recValue.Kind() != reflect.Struct {
+		return recs, nil
+	}
+	idField := recValue.FieldByName("ID")
+	if !idField.IsValid() || idField.Kind() != reflect.String || !idField.IsZero() {
+		return recs, nil
+	}
+	id, err := NewId(ModelIdType(recs, defaultIdType))
+	if err != nil {
+		return nil, err
+	}
+	return SetRecordsField(recs, "ID", id, true)
+}
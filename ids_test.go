@@ -0,0 +1,60 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: client-side record-ids test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRecordIds(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-([47])[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	mctest.McTest(mctest.OptionValue{
+		Name: "should generate the uuid v4 / v7 ids:",
+		TestFunc: func() {
+			id, err := NewId(UuidV4Id)
+			mctest.AssertEquals(t, err, nil, "uuid-v4 error should be: nil")
+			mctest.AssertEquals(t, uuidPattern.FindStringSubmatch(id)[1], "4", "uuid version should be: 4")
+			id, err = NewId(UuidV7Id)
+			mctest.AssertEquals(t, err, nil, "uuid-v7 error should be: nil")
+			mctest.AssertEquals(t, uuidPattern.FindStringSubmatch(id)[1], "7", "uuid version should be: 7")
+			_, err = NewId("serial")
+			mctest.AssertEquals(t, err != nil, true, "unknown id-type error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should generate the time-ordered uuid-v7 and ulid ids:",
+		TestFunc: func() {
+			firstUuid, _ := NewUuidV7()
+			firstUlid, _ := NewUlid()
+			time.Sleep(2 * time.Millisecond)
+			secondUuid, _ := NewUuidV7()
+			secondUlid, _ := NewUlid()
+			mctest.AssertEquals(t, firstUuid < secondUuid, true, "uuid-v7 ids should be: time-ordered")
+			mctest.AssertEquals(t, firstUlid < secondUlid, true, "ulid ids should be: time-ordered")
+			mctest.AssertEquals(t, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`).MatchString(firstUlid), true, "ulid should be: 26-char Crockford base32")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set the empty record-ids only, by the model or default id-type:",
+		TestFunc: func() {
+			recs := []Group{{Name: "Admin"}, {BaseModelType: BaseModelType{ID: "group-100"}, Name: "User"}}
+			idRecs, err := SetRecordIds(recs, UuidV7Id)
+			mctest.AssertEquals(t, err, nil, "set-ids error should be: nil")
+			groups := idRecs.([]Group)
+			mctest.AssertEquals(t, uuidPattern.FindStringSubmatch(groups[0].ID)[1], "7", "new id version should be: 7")
+			mctest.AssertEquals(t, groups[1].ID, "group-100", "existing id should be: group-100")
+			mctest.AssertEquals(t, ModelIdType(Audit{}, UuidV4Id), UuidV7Id, "audit id-type should be: uuidV7")
+			mctest.AssertEquals(t, ModelIdType(&Group{}, UlidId), UlidId, "group id-type should be: the default ulid")
+			mapRecs, err := SetRecordIds(map[string]interface{}{"name": "Admin"}, UuidV4Id)
+			mctest.AssertEquals(t, err, nil, "map-record error should be: nil")
+			mctest.AssertEquals(t, len(mapRecs.(map[string]interface{})), 1, "map-record should be: unchanged")
+		},
+	})
+
+	mctest.PostTestResult()
+}
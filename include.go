@@ -0,0 +1,236 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - eager-loading (preload) of the included associations, for the get/read tasks
+
+package mcgorm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+)
+
+// IncludeAssociationType describes the validated include-param association, for the preload and the record-maps
+type IncludeAssociationType struct {
+	Preload  string   // gorm preload name, e.g. Parent.Group
+	JsonPath []string // record-map keys, e.g. parent, group
+	Columns  []string // projected association table-columns, with the primary/reference keys, all if empty
+	KeepKeys []string // projected record-map keys of the association, with the nested included associations
+	relation *schema.Relationship
+}
+
+// jsonFieldName returns the json (record-map) key of the model field, or the field name, if not json-tagged
+func jsonFieldName(field *schema.Field) string {
+	jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+	if jsonName == "" {
+		return field.Name
+	}
+	return jsonName
+}
+
+// includeRelation returns the association (relationship) of the model-schema, by the field or json name
+func includeRelation(modelSchema *schema.Schema, name string) *schema.Relationship {
+	for fieldName, relation := range modelSchema.Relationships.Relations {
+		if strings.EqualFold(fieldName, name) || jsonFieldName(relation.Field) == name {
+			return relation
+		}
+	}
+	return nil
+}
+
+// includeField returns the (table-column) field of the model-schema, by the column, field or json name
+func includeField(modelSchema *schema.Schema, name string) *schema.Field {
+	if field := modelSchema.LookUpField(name); field != nil && field.DBName != "" {
+		return field
+	}
+	for _, field := range modelSchema.Fields {
+		if field.DBName != "" && jsonFieldName(field) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// IncludeAssociations function validates the include-params paths (e.g. group, parent.group) and projected fields
+// against the model-schema associations, and returns the include-associations, for the preload
+func IncludeAssociations(modelSchema *schema.Schema, includeParams []IncludeParamType) ([]IncludeAssociationType, error) {
+	var associations []IncludeAssociationType
+	for _, includeParam := range includeParams {
+		if includeParam.Path == "" {
+			return nil, NewError("paramsError", "Include path is required", nil)
+		}
+		association := IncludeAssociationType{}
+		var preloadNames []string
+		currentSchema := modelSchema
+		var relation *schema.Relationship
+		for _, name := range strings.Split(includeParam.Path, ".") {
+			relation = includeRelation(currentSchema, name)
+			if relation == nil {
+				return nil, NewError("paramsError", fmt.Sprintf("Include path %v: %v is not a valid %v association", includeParam.Path, name, currentSchema.Name), nil)
+			}
+			preloadNames = append(preloadNames, relation.Name)
+			association.JsonPath = append(association.JsonPath, jsonFieldName(relation.Field))
+			currentSchema = relation.FieldSchema
+		}
+		association.Preload = strings.Join(preloadNames, ".")
+		association.relation = relation
+		for _, item := range associations {
+			if item.Preload == association.Preload {
+				return nil, NewError("paramsError", fmt.Sprintf("Duplicate include path: %v", includeParam.Path), nil)
+			}
+		}
+		// projected fields, with the primary and reference keys of the association
+		if len(includeParam.Fields) > 0 {
+			keyFields := append([]*schema.Field{}, currentSchema.PrimaryFields...)
+			for _, reference := range relation.References {
+				if reference.PrimaryKey != nil && reference.PrimaryKey.Schema == currentSchema {
+					keyFields = append(keyFields, reference.PrimaryKey)
+				}
+				if reference.ForeignKey != nil && reference.ForeignKey.Schema == currentSchema {
+					keyFields = append(keyFields, reference.ForeignKey)
+				}
+			}
+			for _, fieldName := range includeParam.Fields {
+				field := includeField(currentSchema, fieldName)
+				if field == nil {
+					return nil, NewError("paramsError", fmt.Sprintf("Include field %v is not a valid %v field", fieldName, currentSchema.Name), nil)
+				}
+				if !ArrayStringContains(association.KeepKeys, jsonFieldName(field)) {
+					association.KeepKeys = append(association.KeepKeys, jsonFieldName(field))
+				}
+				keyFields = append(keyFields, field)
+			}
+			for _, field := range keyFields {
+				if !ArrayStringContains(association.Columns, field.DBName) {
+					association.Columns = append(association.Columns, field.DBName)
+				}
+			}
+		}
+		associations = append(associations, association)
+	}
+	// keep the nested included associations and their reference keys, in the projected record-maps
+	for index, association := range associations {
+		if len(association.Columns) < 1 {
+			continue
+		}
+		depth := len(association.JsonPath)
+		for _, item := range associations {
+			if len(item.JsonPath) <= depth || !strings.HasPrefix(item.Preload, association.Preload+".") {
+				continue
+			}
+			if !ArrayStringContains(associations[index].KeepKeys, item.JsonPath[depth]) {
+				associations[index].KeepKeys = append(associations[index].KeepKeys, item.JsonPath[depth])
+			}
+			if len(item.JsonPath) > depth+1 {
+				continue
+			}
+			for _, reference := range item.relation.References {
+				for _, field := range []*schema.Field{reference.PrimaryKey, reference.ForeignKey} {
+					if field != nil && field.Schema == item.relation.Schema && !ArrayStringContains(associations[index].Columns, field.DBName) {
+						associations[index].Columns = append(associations[index].Columns, field.DBName)
+					}
+				}
+			}
+		}
+	}
+	return associations, nil
+}
+
+// projectIncludeValue keeps the projected keys of the association record-map(s), at the json-path
+func projectIncludeValue(value interface{}, jsonPath []string, keepKeys []string) {
+	switch val := value.(type) {
+	case []interface{}:
+		for _, item := range val {
+			projectIncludeValue(item, jsonPath, keepKeys)
+		}
+	case map[string]interface{}:
+		if len(jsonPath) < 1 {
+			for key := range val {
+				if !ArrayStringContains(keepKeys, key) {
+					delete(val, key)
+				}
+			}
+			return
+		}
+		projectIncludeValue(val[jsonPath[0]], jsonPath[1:], keepKeys)
+	}
+}
+
+// IncludeRecords method eager-loads (preloads) the included associations of the get-records (record-maps), by the
+// record-ids, and sets the (projected) association values in the record-maps
+func (crud Crud) IncludeRecords(modelRef interface{}, records []interface{}) ([]interface{}, error) {
+	if len(crud.IncludeParams) < 1 {
+		return records, nil
+	}
+	stmt := &gorm.Statement{DB: crud.GormDb}
+	if err := stmt.Parse(modelRef); err != nil {
+		return nil, NewError("paramsError", fmt.Sprintf("%v", err.Error()), err)
+	}
+	associations, err := IncludeAssociations(stmt.Schema, crud.IncludeParams)
+	if err != nil {
+		return nil, err
+	}
+	recordIds := RecordIdsFrom(records)
+	if len(recordIds) < 1 {
+		return records, nil
+	}
+	modelType := reflect.Indirect(reflect.ValueOf(modelRef)).Type()
+	if modelType.Kind() != reflect.Struct {
+		return nil, errors.New(fmt.Sprintf("modelRef parameter must be of type struct{}: %v", modelType.Kind()))
+	}
+	// preload the associations, with the projected columns, restricted to the current app/tenant for the
+	// associations with the app_id column
+	query := crud.GormDb.Scopes(crud.AppScope)
+	for _, association := range associations {
+		columns := association.Columns
+		appScoped := association.relation.FieldSchema.LookUpField("app_id") != nil
+		query = query.Preload(association.Preload, func(db *gorm.DB) *gorm.DB {
+			if appScoped {
+				db = db.Scopes(crud.AppScope)
+			}
+			if len(columns) > 0 {
+				db = db.Select(columns)
+			}
+			return db
+		})
+	}
+	modelRecs := reflect.New(reflect.SliceOf(modelType))
+	if err = query.Where("id IN ?", recordIds).Find(modelRecs.Interface()).Error; err != nil {
+		return nil, err
+	}
+	jByte, err := json.Marshal(modelRecs.Interface())
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error transforming records into json-value([]byte): %v", err.Error()))
+	}
+	var includeRecs []map[string]interface{}
+	if err = json.Unmarshal(jByte, &includeRecs); err != nil {
+		return nil, errors.New(fmt.Sprintf("Error transforming json-value to result-value: %v", err.Error()))
+	}
+	includeRecsById := map[string]map[string]interface{}{}
+	for _, rec := range includeRecs {
+		includeRecsById[fmt.Sprintf("%v", rec["id"])] = rec
+	}
+	for _, record := range records {
+		recMap, ok := record.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		includeRec, ok := includeRecsById[fmt.Sprintf("%v", recMap["id"])]
+		if !ok {
+			continue
+		}
+		for _, association := range associations {
+			recMap[association.JsonPath[0]] = includeRec[association.JsonPath[0]]
+		}
+		for _, association := range associations {
+			if len(association.KeepKeys) > 0 {
+				projectIncludeValue(recMap, association.JsonPath, association.KeepKeys)
+			}
+		}
+	}
+	return records, nil
+}
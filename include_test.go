@@ -0,0 +1,69 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: eager-loading (include-params) test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"gorm.io/gorm/schema"
+	"sync"
+	"testing"
+)
+
+func TestIncludeParams(t *testing.T) {
+	categorySchema, err := schema.Parse(&Category{}, &sync.Map{}, schema.NamingStrategy{})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the include paths and compose the preload names:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, err, nil, "category-schema error should be: nil")
+			associations, aErr := IncludeAssociations(categorySchema, []IncludeParamType{{Path: "group"}, {Path: "parent.group"}})
+			mctest.AssertEquals(t, aErr, nil, "include-associations error should be: nil")
+			mctest.AssertEquals(t, len(associations), 2, "include-associations should be: 2")
+			mctest.AssertEquals(t, associations[0].Preload, "Group", "first preload should be: Group")
+			mctest.AssertEquals(t, associations[1].Preload, "Parent.Group", "second preload should be: Parent.Group")
+			mctest.AssertEquals(t, associations[1].JsonPath[0], "parent", "second json-path should be: parent")
+			mctest.AssertEquals(t, len(associations[0].Columns), 0, "unprojected columns should be: 0")
+			_, aErr = IncludeAssociations(categorySchema, []IncludeParamType{{Path: "owner"}})
+			mctest.AssertEquals(t, ErrorToResponse(aErr).Code, "paramsError", "invalid-path code should be: paramsError")
+			_, aErr = IncludeAssociations(categorySchema, []IncludeParamType{{Path: "group"}, {Path: "Group"}})
+			mctest.AssertEquals(t, aErr != nil, true, "duplicate-path error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should project the association fields, with the primary and reference keys:",
+		TestFunc: func() {
+			associations, aErr := IncludeAssociations(categorySchema, []IncludeParamType{
+				{Path: "parent", Fields: []string{"name"}},
+				{Path: "parent.group", Fields: []string{"name"}},
+			})
+			mctest.AssertEquals(t, aErr, nil, "include-associations error should be: nil")
+			parent := associations[0]
+			mctest.AssertEquals(t, ArrayStringContains(parent.Columns, "id"), true, "parent columns should include: id")
+			mctest.AssertEquals(t, ArrayStringContains(parent.Columns, "group_id"), true, "parent columns should include: group_id")
+			mctest.AssertEquals(t, ArrayStringContains(parent.KeepKeys, "group"), true, "parent keep-keys should include: group")
+			mctest.AssertEquals(t, ArrayStringContains(associations[1].Columns, "name"), true, "group columns should include: name")
+			_, aErr = IncludeAssociations(categorySchema, []IncludeParamType{{Path: "group", Fields: []string{"title"}}})
+			mctest.AssertEquals(t, aErr != nil, true, "invalid-field error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should keep the projected keys of the nested record-maps:",
+		TestFunc: func() {
+			record := map[string]interface{}{
+				"id": "id-100",
+				"parent": map[string]interface{}{
+					"id": "id-200", "name": "Books", "path": "/books",
+					"group": map[string]interface{}{"id": "id-300", "name": "Products"},
+				},
+			}
+			projectIncludeValue(record, []string{"parent"}, []string{"id", "name", "group"})
+			parent := record["parent"].(map[string]interface{})
+			mctest.AssertEquals(t, len(parent), 3, "parent keys should be: 3")
+			mctest.AssertEquals(t, parent["path"], nil, "unprojected path should be: nil")
+			mctest.AssertEquals(t, record["id"], "id-100", "record id should be: id-100")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,85 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: json data-type for gorm models - JSONB (PostgreSQL), JSON (MySQL) and TEXT (SQLite)
+
+package mcgorm
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JsonDataType wraps any json-serializable value (map, struct, slice...) for storage in a json/text table-column
+type JsonDataType struct {
+	Data interface{}
+}
+
+// Value implements the driver.Valuer interface
+func (j JsonDataType) Value() (driver.Value, error) {
+	if j.Data == nil {
+		return nil, nil
+	}
+	jByte, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error transforming value to json: %v", err.Error()))
+	}
+	return string(jByte), nil
+}
+
+// Scan implements the sql.Scanner interface
+func (j *JsonDataType) Scan(value interface{}) error {
+	var jByte []byte
+	switch val := value.(type) {
+	case nil:
+		j.Data = nil
+		return nil
+	case []byte:
+		jByte = val
+	case string:
+		jByte = []byte(val)
+	default:
+		return errors.New(fmt.Sprintf("unsupported json column value-type: %T", value))
+	}
+	var data interface{}
+	if err := json.Unmarshal(jByte, &data); err != nil {
+		return errors.New(fmt.Sprintf("error transforming json to value: %v", err.Error()))
+	}
+	j.Data = data
+	return nil
+}
+
+// MarshalJSON returns the json-value of the wrapped data
+func (j JsonDataType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON sets the wrapped data from the json-value
+func (j *JsonDataType) UnmarshalJSON(jByte []byte) error {
+	var data interface{}
+	if err := json.Unmarshal(jByte, &data); err != nil {
+		return err
+	}
+	j.Data = data
+	return nil
+}
+
+// GormDataType returns the general gorm data-type
+func (JsonDataType) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType returns the table-column type, by database dialect
+func (JsonDataType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "JSONB"
+	case "mysql":
+		return "JSON"
+	default:
+		return "TEXT"
+	}
+}
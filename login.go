@@ -0,0 +1,342 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud - login, logout and access-token methods
+
+package mcgorm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"golang.org/x/crypto/bcrypt"
+	"sync"
+	"time"
+)
+
+// LoginResultType for Login method value (interface{}) response,
+// and to assert returned value
+type LoginResultType struct {
+	UserId    string                     `json:"userId"`
+	LoginName string                     `json:"loginName"`
+	Token     string                     `json:"token"`
+	Expire    int64                      `json:"expire"` // in milliseconds
+	LogRes    mcresponse.ResponseMessage `json:"logRes"`
+}
+
+// dummyPasswordHash is the (default-cost) bcrypt-hash compared for the unknown login-names, for the same login
+// response time of the unknown and the known login-names
+const dummyPasswordHash = "$2a$10$lIB6nrYYaf9PRtnkZRi8geHdOeGiINhv76lkgPH6/yqqXq.My02.i"
+
+// HashPassword returns the bcrypt-hash of the password, for storage in the user-table
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", errors.New("password is required")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("error hashing password: %v", err.Error()))
+	}
+	return string(hash), nil
+}
+
+// CheckPassword validates the password against the stored bcrypt-hash
+func CheckPassword(hash string, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// GenerateToken returns a random hex-encoded access-token
+func GenerateToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", errors.New(fmt.Sprintf("error generating access-token: %v", err.Error()))
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// Login method verifies the user loginName (email or username) and password, and issues an access-token,
+// with expiry (loginTimeout), in the accessTable
+func (crud *Crud) Login(loginName string, password string) mcresponse.ResponseMessage {
+	if loginName == "" || password == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Login-name and password are required",
+			Value:   nil,
+		})
+	}
+	// get user information, by email or username
+	emailUsername := EmailUsername(loginName)
+	var userScript string
+	if emailUsername.Email != "" {
+		userScript = fmt.Sprintf("SELECT id, password, is_active from %v WHERE email=$1", crud.UserTable)
+	} else {
+		userScript = fmt.Sprintf("SELECT id, password, is_active from %v WHERE username=$1", crud.UserTable)
+	}
+	var (
+		userId       string
+		passwordHash string
+		isActive     bool
+	)
+	userRow := crud.AccessDb.QueryRow(context.Background(), userScript, loginName)
+	if err := userRow.Scan(&userId, &passwordHash, &isActive); err != nil {
+		// compare the dummy-hash, for the same response time as the invalid password
+		CheckPassword(dummyPasswordHash, password)
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Invalid login-name or password",
+			Value:   nil,
+		})
+	}
+	if !CheckPassword(passwordHash, password) {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Invalid login-name or password",
+			Value:   nil,
+		})
+	}
+	if !isActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Account is not active. Validate active status",
+			Value:   nil,
+		})
+	}
+
+	// issue access-token
+	token, err := GenerateToken()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	expire := time.Now().Add(time.Duration(crud.LoginTimeout)*time.Second).UnixNano() / int64(time.Millisecond)
+	accessScript := fmt.Sprintf("INSERT INTO %v(user_id, login_name, token, expire) VALUES($1, $2, $3, $4)", crud.AccessTable)
+	if _, err = crud.AccessDb.Exec(context.Background(), accessScript, userId, loginName, token, expire); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating access-information: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+
+	// LogLogin
+	var logRes mcresponse.ResponseMessage
+	if crud.LogLogin {
+		logRes, err = crud.TransLog.AuditLog(LoginLog, userId, AuditLogOptionsType{
+			LogRecords:  map[string]interface{}{"loginName": loginName, "expire": expire},
+			TableName:   crud.AccessTable,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Login completed successfully.",
+		Value: LoginResultType{
+			UserId:    userId,
+			LoginName: loginName,
+			Token:     token,
+			Expire:    expire,
+			LogRes:    logRes,
+		},
+	})
+}
+
+// Logout method revokes the access-token, by removing the access-information from the accessTable
+func (crud *Crud) Logout(token string) mcresponse.ResponseMessage {
+	if token == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Access-token is required",
+			Value:   nil,
+		})
+	}
+	var (
+		userId    string
+		loginName string
+	)
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE token=$1 RETURNING user_id, login_name", crud.AccessTable)
+	delRow := crud.AccessDb.QueryRow(context.Background(), delScript, token)
+	if err := delRow.Scan(&userId, &loginName); err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Access information not found or already logged-out",
+			Value:   nil,
+		})
+	}
+
+	InvalidateAccessCache(userId)
+
+	// LogLogout
+	var logRes mcresponse.ResponseMessage
+	var err error
+	if crud.LogLogout {
+		logRes, err = crud.TransLog.AuditLog(LogoutLog, userId, AuditLogOptionsType{
+			LogRecords:  map[string]interface{}{"loginName": loginName},
+			TableName:   crud.AccessTable,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Logout completed successfully.",
+		Value: CrudResultType{
+			RecordCount: 1,
+			TaskType:    CrudTasks().Logout,
+			LogRes:      logRes,
+		},
+	})
+}
+
+// TokenRefreshDue method returns true, if the sliding-expiry (SlidingExpiry) access-token is due for the refresh, i.e.
+// within half of the loginTimeout of its expiry (tokenExpire, unix-ms), to limit the access-token updates
+func (crud *Crud) TokenRefreshDue(tokenExpire int64) bool {
+	if !crud.SlidingExpiry {
+		return false
+	}
+	refreshAt := time.Unix(0, tokenExpire*int64(time.Millisecond)).Add(-time.Duration(crud.LoginTimeout) * time.Second / 2)
+	return !time.Now().Before(refreshAt)
+}
+
+// RefreshToken method extends the expiry (by loginTimeout) of a valid/un-expired access-token
+func (crud *Crud) RefreshToken(token string) mcresponse.ResponseMessage {
+	if token == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Access-token is required",
+			Value:   nil,
+		})
+	}
+	var (
+		userId    string
+		loginName string
+	)
+	now := time.Now()
+	expire := now.Add(time.Duration(crud.LoginTimeout)*time.Second).UnixNano() / int64(time.Millisecond)
+	refreshScript := fmt.Sprintf("UPDATE %v SET expire=$1 WHERE token=$2 AND expire>$3 RETURNING user_id, login_name", crud.AccessTable)
+	refreshRow := crud.AccessDb.QueryRow(context.Background(), refreshScript, expire, token, now.UnixNano()/int64(time.Millisecond))
+	if err := refreshRow.Scan(&userId, &loginName); err != nil {
+		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Access information not found or expired: please login to continue",
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Access-token refreshed successfully.",
+		Value: LoginResultType{
+			UserId:    userId,
+			LoginName: loginName,
+			Token:     token,
+			Expire:    expire,
+		},
+	})
+}
+
+// RevokeUserTokens method revokes all the access-tokens of the user (e.g. logout from all devices)
+func (crud *Crud) RevokeUserTokens(userId string) mcresponse.ResponseMessage {
+	if userId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId is required",
+			Value:   nil,
+		})
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.AccessTable)
+	cmdTag, err := crud.AccessDb.Exec(context.Background(), delScript, userId)
+	if err != nil {
+		return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking access-tokens: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+
+	InvalidateAccessCache(userId)
+
+	// LogLogout
+	var logRes mcresponse.ResponseMessage
+	if crud.LogLogout {
+		logRes, err = crud.TransLog.AuditLog(LogoutLog, userId, AuditLogOptionsType{
+			LogRecords:  map[string]interface{}{"userId": userId, "revokedTokens": cmdTag.RowsAffected()},
+			TableName:   crud.AccessTable,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Access-tokens revoked successfully.",
+		Value: CrudResultType{
+			RecordCount: int(cmdTag.RowsAffected()),
+			TaskType:    CrudTasks().Logout,
+			LogRes:      logRes,
+		},
+	})
+}
+
+// DeleteExpiredTokens method purges the expired access-tokens from the accessTable, in batches of batchSize rows
+func (crud *Crud) DeleteExpiredTokens(batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = 10000
+	}
+	var deletedCount int64
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE ctid IN (SELECT ctid FROM %v WHERE expire<$1 LIMIT $2)", crud.AccessTable, crud.AccessTable)
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for {
+		cmdTag, err := crud.AccessDb.Exec(context.Background(), delScript, now, batchSize)
+		if err != nil {
+			return deletedCount, errors.New(fmt.Sprintf("error deleting expired access-tokens: %v", err.Error()))
+		}
+		deletedCount += cmdTag.RowsAffected()
+		if cmdTag.RowsAffected() < int64(batchSize) {
+			break
+		}
+	}
+	return deletedCount, nil
+}
+
+// StartTokenSweeper starts a background task that purges the expired access-tokens on every interval,
+// and returns the stop function. The optional onSweep function receives the result of each sweep.
+func (crud *Crud) StartTokenSweeper(interval time.Duration, onSweep func(deletedCount int64, err error)) (stop func()) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				deletedCount, err := crud.DeleteExpiredTokens(0)
+				if onSweep != nil {
+					onSweep(deletedCount, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
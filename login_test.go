@@ -0,0 +1,25 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: login and access-token test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func TestLogin(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compare the unknown login-name password with the default-cost dummy-hash:",
+		TestFunc: func() {
+			cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+			mctest.AssertEquals(t, err, nil, "dummy-hash error should be: nil")
+			mctest.AssertEquals(t, cost, bcrypt.DefaultCost, "dummy-hash cost should be: default-cost")
+			mctest.AssertEquals(t, CheckPassword(dummyPasswordHash, "password"), false, "dummy-hash password check should be: false")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,594 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - versioned schema-migrations (up/down by dialect), and the built-in audit/access migrations
+
+package mcgorm
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MigrationType describes a versioned schema-migration: the up/down sql-statements by dialect (postgres, mysql, sqlite),
+// and/or the Go up/down functions (e.g. model AutoMigrate)
+type MigrationType struct {
+	Version  string              // sortable version, e.g. 20261019000001
+	Name     string              // description, part of the checksum
+	Up       map[string][]string // dialect => sql-statements
+	Down     map[string][]string // dialect => sql-statements, empty for the irreversible/no-op down-migration
+	UpFunc   func(tx *gorm.DB) error
+	DownFunc func(tx *gorm.DB) error
+}
+
+// SchemaMigration describe the data-model for the applied migrations, in the migrations-table
+type SchemaMigration struct {
+	Version   string    `json:"version" gorm:"primaryKey" mcorm:"version"`
+	Name      string    `json:"name" mcorm:"name"`
+	Checksum  string    `json:"checksum" mcorm:"checksum"`
+	Dialect   string    `json:"dialect" mcorm:"dialect"`
+	AppliedAt time.Time `json:"appliedAt" mcorm:"applied_at"`
+}
+
+// SchemaMigrator applies / rolls back the migrations, recorded in the migrations-table
+type SchemaMigrator struct {
+	Db             *gorm.DB
+	Dialect        string // gorm dialector name: postgres | mysql | sqlite
+	MigrationTable string // default: schema_migrations
+	Migrations     []MigrationType
+}
+
+// DefaultMigrationTable is the default migrations-table name
+const DefaultMigrationTable = "schema_migrations"
+
+// MigrationDialects are the supported migration dialects (gorm dialector names)
+var MigrationDialects = []string{"postgres", "mysql", "sqlite"}
+
+// NewSchemaMigrator constructor returns a new schema-migrator, for the db dialect
+func NewSchemaMigrator(db *gorm.DB, migrationTable string, migrations []MigrationType) *SchemaMigrator {
+	result := &SchemaMigrator{}
+	result.Db = db
+	result.MigrationTable = migrationTable
+	result.Migrations = migrations
+	// default values
+	if result.MigrationTable == "" {
+		result.MigrationTable = DefaultMigrationTable
+	}
+	if db != nil && db.Dialector != nil {
+		result.Dialect = db.Dialector.Name()
+	}
+	return result
+}
+
+// MigrationChecksum returns the sha256 (hex) checksum of the migration name and up-statements, for the dialect
+func MigrationChecksum(migration MigrationType, dialect string) string {
+	hash := sha256.Sum256([]byte(migration.Name + "\n" + strings.Join(migration.Up[dialect], ";\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// sortedMigrations method returns the validated migrations, in version order; the duplicate identical migrations
+// (e.g. the shared uuid-extension migration) are included once
+func (migrator *SchemaMigrator) sortedMigrations() ([]MigrationType, error) {
+	migrationMap := map[string]MigrationType{}
+	var migrations []MigrationType
+	for _, migration := range migrator.Migrations {
+		if migration.Version == "" {
+			return nil, errors.New(fmt.Sprintf("version is required for the migration: %v", migration.Name))
+		}
+		if _, ok := migration.Up[migrator.Dialect]; !ok && migration.UpFunc == nil {
+			return nil, errors.New(fmt.Sprintf("no %v up-migration for the migration %v (%v)", migrator.Dialect, migration.Version, migration.Name))
+		}
+		if existing, ok := migrationMap[migration.Version]; ok {
+			if MigrationChecksum(existing, migrator.Dialect) != MigrationChecksum(migration, migrator.Dialect) {
+				return nil, errors.New(fmt.Sprintf("duplicate migration version: %v", migration.Version))
+			}
+			continue
+		}
+		migrationMap[migration.Version] = migration
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Setup method creates/migrates the migrations-table
+func (migrator *SchemaMigrator) Setup() error {
+	if err := migrator.Db.Table(migrator.MigrationTable).AutoMigrate(&SchemaMigration{}); err != nil {
+		return errors.New(fmt.Sprintf("error migrating migrations-table %v: %v", migrator.MigrationTable, err.Error()))
+	}
+	return nil
+}
+
+// Applied method returns the applied migrations, in version order
+func (migrator *SchemaMigrator) Applied() ([]SchemaMigration, error) {
+	if err := migrator.Setup(); err != nil {
+		return nil, err
+	}
+	var applied []SchemaMigration
+	if err := migrator.Db.Table(migrator.MigrationTable).Order("version asc").Find(&applied).Error; err != nil {
+		return nil, errors.New(fmt.Sprintf("error reading the applied migrations: %v", err.Error()))
+	}
+	return applied, nil
+}
+
+// Pending method returns the not-yet applied migrations, in version order; the applied migrations are verified by checksum
+func (migrator *SchemaMigrator) Pending() ([]MigrationType, error) {
+	migrations, err := migrator.sortedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := migrator.Applied()
+	if err != nil {
+		return nil, err
+	}
+	appliedMap := map[string]SchemaMigration{}
+	for _, appliedMigration := range applied {
+		appliedMap[appliedMigration.Version] = appliedMigration
+	}
+	var pending []MigrationType
+	for _, migration := range migrations {
+		appliedMigration, ok := appliedMap[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if appliedMigration.Checksum != MigrationChecksum(migration, migrator.Dialect) {
+			return nil, errors.New(fmt.Sprintf("checksum mismatch for the applied migration %v (%v): the migration was modified after it was applied",
+				migration.Version, migration.Name))
+		}
+	}
+	return pending, nil
+}
+
+// Migrate method applies the pending migrations, in version order, each in a transaction, holding the migrations
+// lock, and returns the applied migration-versions
+func (migrator *SchemaMigrator) Migrate() ([]string, error) {
+	var versions []string
+	err := migrator.withLock(func() error {
+		var err error
+		versions, err = migrator.migrate()
+		return err
+	})
+	return versions, err
+}
+
+// migrate method applies the pending migrations (migrations lock held by the caller)
+func (migrator *SchemaMigrator) migrate() ([]string, error) {
+	pending, err := migrator.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, migration := range pending {
+		err = migrator.Db.Transaction(func(tx *gorm.DB) error {
+			for _, statement := range migration.Up[migrator.Dialect] {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			if migration.UpFunc != nil {
+				if err := migration.UpFunc(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Table(migrator.MigrationTable).Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  MigrationChecksum(migration, migrator.Dialect),
+				Dialect:   migrator.Dialect,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return versions, errors.New(fmt.Sprintf("error applying the migration %v (%v): %v", migration.Version, migration.Name, err.Error()))
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Rollback method reverts the last applied migrations (default: 1 step), in reverse version order, each in a transaction,
+// holding the migrations lock, and returns the reverted migration-versions
+func (migrator *SchemaMigrator) Rollback(steps int) ([]string, error) {
+	var versions []string
+	err := migrator.withLock(func() error {
+		var err error
+		versions, err = migrator.rollback(steps)
+		return err
+	})
+	return versions, err
+}
+
+// rollback method reverts the last applied migrations (migrations lock held by the caller)
+func (migrator *SchemaMigrator) rollback(steps int) ([]string, error) {
+	if steps < 1 {
+		steps = 1
+	}
+	migrations, err := migrator.sortedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	migrationMap := map[string]MigrationType{}
+	for _, migration := range migrations {
+		migrationMap[migration.Version] = migration
+	}
+	applied, err := migrator.Applied()
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for i := len(applied) - 1; i >= 0 && len(versions) < steps; i-- {
+		migration, ok := migrationMap[applied[i].Version]
+		if !ok {
+			return versions, errors.New(fmt.Sprintf("migration %v (%v) not found, for the rollback", applied[i].Version, applied[i].Name))
+		}
+		if _, ok = migration.Down[migrator.Dialect]; !ok && migration.DownFunc == nil {
+			return versions, errors.New(fmt.Sprintf("no %v down-migration for the migration %v (%v)", migrator.Dialect, migration.Version, migration.Name))
+		}
+		err = migrator.Db.Transaction(func(tx *gorm.DB) error {
+			if migration.DownFunc != nil {
+				if err := migration.DownFunc(tx); err != nil {
+					return err
+				}
+			}
+			for _, statement := range migration.Down[migrator.Dialect] {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Table(migrator.MigrationTable).Where("version = ?", migration.Version).Delete(&SchemaMigration{}).Error
+		})
+		if err != nil {
+			return versions, errors.New(fmt.Sprintf("error reverting the migration %v (%v): %v", migration.Version, migration.Name, err.Error()))
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// MigrationLockTimeout is the maximum wait for the migrations lock, held by a concurrent migrator (e.g. app instance)
+var MigrationLockTimeout = time.Minute
+
+// schemaMigrationLock describes the lock-row of the (sqlite) migrations lock-table
+type schemaMigrationLock struct {
+	Id       int `gorm:"primaryKey;autoIncrement:false"`
+	LockedAt time.Time
+}
+
+// advisoryLockKey returns the (postgres) advisory-lock key of the lock-name, e.g. of the migrations or audit-chain lock
+func advisoryLockKey(lockName string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(lockName))
+	return int64(hash.Sum64())
+}
+
+// withLock method runs the task holding the migrations lock, so that the concurrent migrators read the pending
+// migrations and apply them once: a session advisory-lock (postgres, mysql), on a dedicated connection, or the lock-row
+// of the migrations lock-table (sqlite)
+func (migrator *SchemaMigrator) withLock(task func() error) error {
+	lockName := migrator.MigrationTable + "_lock"
+	if migrator.Dialect != "postgres" && migrator.Dialect != "mysql" {
+		return migrator.withLockRow(lockName, task)
+	}
+	sqlDb, err := migrator.Db.DB()
+	if err != nil {
+		return errors.New(fmt.Sprintf("error acquiring the migrations lock %v: %v", lockName, err.Error()))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), MigrationLockTimeout)
+	defer cancel()
+	conn, err := sqlDb.Conn(ctx)
+	if err != nil {
+		return errors.New(fmt.Sprintf("error acquiring the migrations lock %v: %v", lockName, err.Error()))
+	}
+	defer conn.Close()
+	if migrator.Dialect == "postgres" {
+		lockKey := advisoryLockKey(lockName)
+		if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+			return errors.New(fmt.Sprintf("error acquiring the migrations lock %v: %v", lockName, err.Error()))
+		}
+		defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+	} else {
+		var locked sql.NullInt64
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(MigrationLockTimeout.Seconds())).Scan(&locked)
+		if err != nil {
+			return errors.New(fmt.Sprintf("error acquiring the migrations lock %v: %v", lockName, err.Error()))
+		}
+		if locked.Int64 != 1 {
+			return errors.New(fmt.Sprintf("migrations lock %v not acquired, within %v", lockName, MigrationLockTimeout))
+		}
+		defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+	}
+	return task()
+}
+
+// withLockRow method runs the task holding the lock-row of the migrations lock-table, inserted once by primary-key;
+// the lock-row of an interrupted migrator must be removed manually
+func (migrator *SchemaMigrator) withLockRow(lockName string, task func() error) error {
+	if err := migrator.Db.Table(lockName).AutoMigrate(&schemaMigrationLock{}); err != nil {
+		return errors.New(fmt.Sprintf("error migrating the migrations lock-table %v: %v", lockName, err.Error()))
+	}
+	lockUntil := time.Now().Add(MigrationLockTimeout)
+	for {
+		err := migrator.Db.Table(lockName).Create(&schemaMigrationLock{Id: 1, LockedAt: time.Now()}).Error
+		if err == nil {
+			break
+		}
+		if time.Now().After(lockUntil) {
+			return errors.New(fmt.Sprintf("migrations lock %v not acquired, within %v: %v", lockName, MigrationLockTimeout, err.Error()))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	defer migrator.Db.Table(lockName).Where("id = ?", 1).Delete(&schemaMigrationLock{})
+	return task()
+}
+
+// ModelMigration returns the (Go) migration creating/migrating the table by the model (gorm AutoMigrate),
+// and dropping the table on rollback
+func ModelMigration(version string, tableName string, modelRef interface{}) MigrationType {
+	return MigrationType{
+		Version: version,
+		Name:    fmt.Sprintf("create table %v (%T)", tableName, modelRef),
+		UpFunc: func(tx *gorm.DB) error {
+			return tx.Table(tableName).AutoMigrate(modelRef)
+		},
+		DownFunc: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(tableName)
+		},
+	}
+}
+
+// migrationColumn describes the table-column of the built-in migrations
+type migrationColumn struct {
+	Name    string
+	Type    string // id | ref | string | text | bool | bigint | timestamp | timestampNow | json | stringArray
+	Options string // e.g. NOT NULL UNIQUE, DEFAULT FALSE
+}
+
+// table-column types of the built-in migrations, by dialect; the id-defaults are for the raw-sql inserts
+// (e.g. register, login), the crud-tasks set the client-side ids
+var migrationColumnTypes = map[string]map[string]string{
+	"postgres": {
+		"id":           "UUID PRIMARY KEY DEFAULT uuid_generate_v4()",
+		"ref":          "UUID",
+		"string":       "VARCHAR(255)",
+		"text":         "TEXT",
+		"bool":         "BOOLEAN",
+		"bigint":       "BIGINT",
+		"timestamp":    "TIMESTAMPTZ",
+		"timestampNow": "TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP",
+		"json":         "JSONB",
+		"stringArray":  "TEXT[]",
+	},
+	"mysql": {
+		"id":           "VARCHAR(36) PRIMARY KEY DEFAULT (UUID())",
+		"ref":          "VARCHAR(36)",
+		"string":       "VARCHAR(255)",
+		"text":         "TEXT",
+		"bool":         "BOOLEAN",
+		"bigint":       "BIGINT",
+		"timestamp":    "DATETIME(6)",
+		"timestampNow": "DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)",
+		"json":         "JSON",
+		"stringArray":  "JSON",
+	},
+	"sqlite": {
+		"id":           "TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16))))",
+		"ref":          "TEXT",
+		"string":       "TEXT",
+		"text":         "TEXT",
+		"bool":         "BOOLEAN",
+		"bigint":       "INTEGER",
+		"timestamp":    "DATETIME",
+		"timestampNow": "DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP",
+		"json":         "TEXT",
+		"stringArray":  "TEXT",
+	},
+}
+
+// quoteIdentifier returns the quoted table/column name, for the dialect
+func quoteIdentifier(dialect string, name string) string {
+	if dialect == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+// createTableStatements returns the create-table and create-index statements, for the dialect
+func createTableStatements(dialect string, tableName string, columns []migrationColumn, indexFields []string) []string {
+	var columnScripts []string
+	for _, column := range columns {
+		columnScript := quoteIdentifier(dialect, column.Name) + " " + migrationColumnTypes[dialect][column.Type]
+		if column.Options != "" {
+			columnScript += " " + column.Options
+		}
+		columnScripts = append(columnScripts, columnScript)
+	}
+	statements := []string{fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (\n\t%v\n)", quoteIdentifier(dialect, tableName), strings.Join(columnScripts, ",\n\t"))}
+	ifNotExists := "IF NOT EXISTS "
+	if dialect == "mysql" {
+		ifNotExists = ""
+	}
+	for _, field := range indexFields {
+		statements = append(statements, fmt.Sprintf("CREATE INDEX %v%v ON %v (%v)", ifNotExists,
+			quoteIdentifier(dialect, fmt.Sprintf("idx_%v_%v", tableName, field)), quoteIdentifier(dialect, tableName), quoteIdentifier(dialect, field)))
+	}
+	return statements
+}
+
+// dropTableStatements returns the drop-table statements, in the table-names order, for the dialect
+func dropTableStatements(dialect string, tableNames ...string) []string {
+	var statements []string
+	for _, tableName := range tableNames {
+		statements = append(statements, fmt.Sprintf("DROP TABLE IF EXISTS %v", quoteIdentifier(dialect, tableName)))
+	}
+	return statements
+}
+
+// UuidExtensionMigration returns the (postgres) uuid-ossp extension migration, for the uuid_generate_v4() defaults
+func UuidExtensionMigration() MigrationType {
+	return MigrationType{
+		Version: "20261019000001",
+		Name:    "create extension uuid-ossp",
+		Up: map[string][]string{
+			"postgres": {`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`},
+			"mysql":    {},
+			"sqlite":   {},
+		},
+		// the extension is kept on rollback, for the other tables' uuid defaults
+		Down: map[string][]string{
+			"postgres": {},
+			"mysql":    {},
+			"sqlite":   {},
+		},
+	}
+}
+
+// AuditMigrations method returns the built-in migrations for the audit-table
+func (crud Crud) AuditMigrations() []MigrationType {
+	auditColumns := []migrationColumn{
+		{Name: "id", Type: "id"},
+		{Name: "table_name", Type: "string", Options: "NOT NULL"},
+		{Name: "log_records", Type: "json"},
+		{Name: "new_log_records", Type: "json"},
+		{Name: "log_diffs", Type: "json"},
+		{Name: "log_type", Type: "string", Options: "NOT NULL"},
+		{Name: "log_by", Type: "string"},
+		{Name: "log_at", Type: "timestampNow"},
+		{Name: "app_id", Type: "string"},
+		{Name: "record_ids", Type: "text"},
+		{Name: "query_params", Type: "json"},
+		{Name: "client_ip", Type: "string"},
+		{Name: "user_agent", Type: "text"},
+		{Name: "request_id", Type: "string"},
+		{Name: "correlation_id", Type: "string"},
+		{Name: "hash", Type: "string"},
+		{Name: "prev_hash", Type: "string"},
+	}
+	auditMigration := MigrationType{
+		Version: "20261019000002",
+		Name:    fmt.Sprintf("create audit-table %v", crud.AuditTable),
+		Up:      map[string][]string{},
+		Down:    map[string][]string{},
+	}
+	for _, dialect := range MigrationDialects {
+		auditMigration.Up[dialect] = createTableStatements(dialect, crud.AuditTable, auditColumns, AuditIndexFields)
+		auditMigration.Down[dialect] = dropTableStatements(dialect, crud.AuditTable)
+	}
+	return []MigrationType{UuidExtensionMigration(), auditMigration}
+}
+
+// AccessMigrations method returns the built-in migrations for the access-tables: users, roles (role-services),
+// role-inherits, services, profiles, access-keys, verify-users and apps
+func (crud Crud) AccessMigrations() []MigrationType {
+	activeColumn := migrationColumn{Name: "is_active", Type: "bool", Options: "NOT NULL DEFAULT TRUE"}
+	createdColumn := migrationColumn{Name: "created_at", Type: "timestampNow"}
+	tables := []struct {
+		name        string
+		columns     []migrationColumn
+		indexFields []string
+	}{
+		{crud.UserTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "username", Type: "string", Options: "NOT NULL UNIQUE"},
+			{Name: "email", Type: "string", Options: "NOT NULL UNIQUE"},
+			{Name: "password", Type: "string", Options: "NOT NULL"},
+			{Name: "firstname", Type: "string"},
+			{Name: "lastname", Type: "string"},
+			{Name: "language", Type: "string", Options: "DEFAULT 'en-US'"},
+			{Name: "groups", Type: "stringArray"},
+			{Name: "is_admin", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "is_active", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "app_id", Type: "string"},
+			createdColumn,
+			{Name: "updated_at", Type: "timestampNow"},
+		}, nil},
+		{crud.RoleTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "role_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "service_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "service_category", Type: "string"},
+			{Name: "can_read", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "can_create", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "can_update", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "can_delete", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			{Name: "can_crud", Type: "bool", Options: "NOT NULL DEFAULT FALSE"},
+			activeColumn,
+			{Name: "app_id", Type: "string"},
+			createdColumn,
+		}, []string{"role_id", "service_id"}},
+		{crud.RoleInheritTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "role_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "parent_id", Type: "ref", Options: "NOT NULL"},
+			activeColumn,
+			createdColumn,
+		}, []string{"role_id"}},
+		{crud.ServiceTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "name", Type: "string", Options: "NOT NULL UNIQUE"},
+			{Name: "category", Type: "string"},
+			activeColumn,
+			{Name: "app_id", Type: "string"},
+			createdColumn,
+		}, nil},
+		{crud.ProfileTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "user_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "group", Type: "ref"},
+			activeColumn,
+			createdColumn,
+		}, []string{"user_id"}},
+		{crud.AccessTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "user_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "login_name", Type: "string"},
+			{Name: "token", Type: "string", Options: "NOT NULL"},
+			{Name: "expire", Type: "bigint", Options: "NOT NULL"},
+			createdColumn,
+		}, []string{"user_id", "token", "expire"}},
+		{crud.VerifyTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "user_id", Type: "ref", Options: "NOT NULL"},
+			{Name: "code", Type: "string", Options: "NOT NULL"},
+			{Name: "expire", Type: "bigint", Options: "NOT NULL"},
+			createdColumn,
+		}, []string{"user_id", "code"}},
+		{crud.AppTable, []migrationColumn{
+			{Name: "id", Type: "id"},
+			{Name: "app_id", Type: "string", Options: "NOT NULL UNIQUE"},
+			{Name: "app_name", Type: "string"},
+			{Name: "access_key", Type: "string", Options: "NOT NULL"},
+			activeColumn,
+			createdColumn,
+		}, nil},
+	}
+	var accessTables []string
+	for _, table := range tables {
+		accessTables = append(accessTables, table.name)
+	}
+	accessMigration := MigrationType{
+		Version: "20261019000003",
+		Name:    fmt.Sprintf("create access-tables %v", strings.Join(accessTables, ", ")),
+		Up:      map[string][]string{},
+		Down:    map[string][]string{},
+	}
+	for _, dialect := range MigrationDialects {
+		var tableNames []string
+		for _, table := range tables {
+			accessMigration.Up[dialect] = append(accessMigration.Up[dialect], createTableStatements(dialect, table.name, table.columns, table.indexFields)...)
+			tableNames = append([]string{table.name}, tableNames...)
+		}
+		accessMigration.Down[dialect] = dropTableStatements(dialect, tableNames...)
+	}
+	return []MigrationType{UuidExtensionMigration(), accessMigration}
+}
@@ -0,0 +1,134 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema-migrations test cases
+
+package mcgorm
+
+import (
+	"fmt"
+	"github.com/abbeymart/mctest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// migrationTableColumns returns the column-names of the table, from the postgres create-table statements
+func migrationTableColumns(statements []string, tableName string) []string {
+	var columns []string
+	for _, statement := range statements {
+		if !strings.HasPrefix(statement, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%v" (`, tableName)) {
+			continue
+		}
+		for _, match := range regexp.MustCompile(`(?m)^\t"(\w+)"`).FindAllStringSubmatch(statement, -1) {
+			columns = append(columns, match[1])
+		}
+	}
+	return columns
+}
+
+// scriptColumns returns the column-names of the access-control sql-script, i.e. the identifiers except the keywords
+func scriptColumns(script string) []string {
+	keywords := []string{"select", "from", "where", "and", "in", "any"}
+	var columns []string
+	for _, identifier := range regexp.MustCompile(`"?[A-Za-z_]+"?`).FindAllString(strings.Replace(script, "%v", "", -1), -1) {
+		if !ArrayStringContains(keywords, strings.ToLower(identifier)) {
+			columns = append(columns, strings.Trim(identifier, `"`))
+		}
+	}
+	return columns
+}
+
+func TestSchemaMigrations(t *testing.T) {
+	crud := NewCrud(CrudParamsType{}, CrudOptionsType{})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should sort the migrations by version, and include the shared identical migrations once:",
+		TestFunc: func() {
+			migrator := NewSchemaMigrator(nil, "", append(crud.AccessMigrations(), crud.AuditMigrations()...))
+			migrator.Dialect = "postgres"
+			mctest.AssertEquals(t, migrator.MigrationTable, DefaultMigrationTable, "migrations-table should be: schema_migrations")
+			migrations, err := migrator.sortedMigrations()
+			mctest.AssertEquals(t, err, nil, "sorted-migrations error should be: nil")
+			mctest.AssertEquals(t, len(migrations), 3, "migrations should be: 3")
+			mctest.AssertEquals(t, migrations[0].Version, "20261019000001", "first migration should be: uuid-extension")
+			mctest.AssertEquals(t, migrations[1].Version, "20261019000002", "second migration should be: audit-table")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the duplicate versions and the missing dialect migrations:",
+		TestFunc: func() {
+			migrator := NewSchemaMigrator(nil, "", []MigrationType{
+				{Version: "1", Name: "a", Up: map[string][]string{"postgres": {"CREATE TABLE a (id INT)"}}},
+				{Version: "1", Name: "b", Up: map[string][]string{"postgres": {"CREATE TABLE b (id INT)"}}},
+			})
+			migrator.Dialect = "postgres"
+			_, err := migrator.sortedMigrations()
+			mctest.AssertEquals(t, err != nil, true, "duplicate-version error should be: not nil")
+			migrator.Migrations = migrator.Migrations[:1]
+			migrator.Dialect = "mysql"
+			_, err = migrator.sortedMigrations()
+			mctest.AssertEquals(t, err != nil, true, "missing-dialect error should be: not nil")
+			migrator.Migrations = []MigrationType{ModelMigration("2", GroupTable, &Group{})}
+			_, err = migrator.sortedMigrations()
+			mctest.AssertEquals(t, err, nil, "model-migration error should be: nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should change the checksum, with the up-statements:",
+		TestFunc: func() {
+			migration := MigrationType{Version: "1", Name: "a", Up: map[string][]string{"postgres": {"CREATE TABLE a (id INT)"}}}
+			checksum := MigrationChecksum(migration, "postgres")
+			mctest.AssertEquals(t, len(checksum), 64, "checksum length should be: 64")
+			mctest.AssertEquals(t, MigrationChecksum(migration, "postgres"), checksum, "checksum should be: stable")
+			migration.Up["postgres"] = []string{"CREATE TABLE a (id BIGINT)"}
+			mctest.AssertEquals(t, MigrationChecksum(migration, "postgres") != checksum, true, "modified checksum should be: different")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the dialect create/drop-table statements:",
+		TestFunc: func() {
+			auditUp := crud.AuditMigrations()[1].Up
+			mctest.AssertEquals(t, strings.Contains(auditUp["postgres"][0], `"id" UUID PRIMARY KEY DEFAULT uuid_generate_v4()`), true, "postgres id-column should be: uuid")
+			mctest.AssertEquals(t, strings.Contains(auditUp["mysql"][0], "`log_records` JSON"), true, "mysql json-column should be: JSON")
+			mctest.AssertEquals(t, strings.Contains(auditUp["sqlite"][0], `"log_records" TEXT`), true, "sqlite json-column should be: TEXT")
+			mctest.AssertEquals(t, len(auditUp["postgres"]), 1+len(AuditIndexFields), "audit statements should be: table and indexes")
+			accessDown := crud.AccessMigrations()[1].Down["postgres"]
+			mctest.AssertEquals(t, accessDown[0], `DROP TABLE IF EXISTS "apps"`, "first dropped table should be: apps")
+			mctest.AssertEquals(t, accessDown[len(accessDown)-1], `DROP TABLE IF EXISTS "users"`, "last dropped table should be: users")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the access-control scripts on the migrated access-tables columns:",
+		TestFunc: func() {
+			accessUp := crud.AccessMigrations()[1].Up["postgres"]
+			accessScripts := map[string]string{
+				serviceAccessScript: crud.ServiceTable,
+				roleServicesScript:  crud.RoleTable,
+				accessExpireScript:  crud.AccessTable,
+				userAccessScript:    crud.UserTable,
+				profileGroupScript:  crud.ProfileTable,
+				appAccessScript:     crud.AppTable,
+				roleInheritScript:   crud.RoleInheritTable,
+				userRolesScript:     crud.UserTable,
+				serviceIdScript:     crud.ServiceTable,
+			}
+			for script, tableName := range accessScripts {
+				tableColumns := migrationTableColumns(accessUp, tableName)
+				mctest.AssertEquals(t, len(tableColumns) > 0, true, fmt.Sprintf("%v table columns should be: migrated", tableName))
+				for _, column := range scriptColumns(script) {
+					mctest.AssertEquals(t, ArrayStringContains(tableColumns, column), true, fmt.Sprintf("%v column %v should be: migrated", tableName, column))
+				}
+			}
+			mctest.AssertEquals(t, strings.Join(scriptColumns(userAccessScript), ","), "id,groups,is_admin,is_active,id,is_active", "user-access script columns should be: snake-case")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the stable migrations lock-key, by the lock-name:",
+		TestFunc: func() {
+			lockKey := advisoryLockKey(DefaultMigrationTable + "_lock")
+			mctest.AssertEquals(t, advisoryLockKey(DefaultMigrationTable+"_lock"), lockKey, "lock-key should be: stable")
+			mctest.AssertEquals(t, advisoryLockKey("other_lock") != lockKey, true, "other lock-key should be: different")
+		},
+	})
+
+	mctest.PostTestResult()
+}
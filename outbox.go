@@ -0,0 +1,395 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - transactional outbox (change-events) and at-least-once event dispatcher
+
+package mcgorm
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutboxEvent describe the data-model for the change-event (create/update/delete) of the crud-tasks
+type OutboxEvent struct {
+	ID          string       `json:"id" gorm:"primaryKey" mcorm:"id"`
+	TableName   string       `json:"tableName" mcorm:"table_name"`
+	Operation   string       `json:"operation" mcorm:"operation"`  // create | update | delete
+	RecordIds   string       `json:"recordIds" mcorm:"record_ids"` // comma-separated record-ids
+	Payload     JsonDataType `json:"payload" mcorm:"payload"`      // records and/or queryParams
+	AppId       string       `json:"appId" mcorm:"app_id"`
+	CreatedBy   string       `json:"createdBy" mcorm:"created_by"`
+	CreatedAt   time.Time    `json:"createdAt" mcorm:"created_at"`
+	Status      string       `json:"status" mcorm:"status"` // pending | delivered | failed
+	Attempts    int          `json:"attempts" mcorm:"attempts"`
+	LastError   string       `json:"lastError" mcorm:"last_error"`
+	AvailableAt time.Time    `json:"availableAt" mcorm:"available_at"` // next delivery time: retry-backoff or ack-lease
+	DeliveredAt *time.Time   `json:"deliveredAt" mcorm:"delivered_at"`
+}
+
+// outbox-event status
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxFailed    = "failed"
+)
+
+// DefaultOutboxTable is the default outbox-table name
+const DefaultOutboxTable = "outbox_events"
+
+// OutboxIndexFields are the indexed outbox-table fields/columns
+var OutboxIndexFields = []string{"table_name", "status", "available_at"}
+
+// IdType method returns the time-ordered id-type of the outbox-events
+func (OutboxEvent) IdType() string {
+	return UuidV7Id
+}
+
+// Ids method returns the record-ids of the outbox-event
+func (event OutboxEvent) Ids() []string {
+	if event.RecordIds == "" {
+		return []string{}
+	}
+	return strings.Split(event.RecordIds, ",")
+}
+
+// SetupOutboxTable method creates/migrates the outbox-table and the indexes
+func (crud Crud) SetupOutboxTable() error {
+	if err := crud.GormDb.Table(crud.OutboxTable).AutoMigrate(&OutboxEvent{}); err != nil {
+		return errors.New(fmt.Sprintf("error migrating outbox-table %v: %v", crud.OutboxTable, err.Error()))
+	}
+	for _, field := range OutboxIndexFields {
+		indexName := fmt.Sprintf("idx_%v_%v", crud.OutboxTable, field)
+		if crud.GormDb.Migrator().HasIndex(crud.OutboxTable, indexName) {
+			continue
+		}
+		indexScript := fmt.Sprintf("CREATE INDEX %v ON %v (%v)", indexName, crud.OutboxTable, field)
+		if err := crud.GormDb.Exec(indexScript).Error; err != nil {
+			return errors.New(fmt.Sprintf("error creating outbox-table index %v: %v", indexName, err.Error()))
+		}
+	}
+	return nil
+}
+
+// OutboxEnabled method returns true, if the outbox is enabled and the task is a write-task (create/update/delete)
+func (crud Crud) OutboxEnabled(taskType string) bool {
+	return crud.Outbox && ArrayStringContains([]string{CrudTasks().Create, CrudTasks().Update, CrudTasks().Delete}, taskType)
+}
+
+// OutboxRecordIds method returns the record-ids of the update/delete-task, by the recordIds or the queryParams
+func (crud *Crud) OutboxRecordIds() ([]string, error) {
+	if len(crud.RecordIds) > 0 {
+		return crud.RecordIds, nil
+	}
+	if len(crud.QueryParams) < 1 {
+		return nil, nil
+	}
+	qString, _, qValues, qErr := crud.ComputeWhereQuery()
+	if qErr != nil {
+		return nil, qErr
+	}
+	var recordIds []string
+	result := crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).Where(qString, qValues...).Pluck("id", &recordIds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return recordIds, nil
+}
+
+// WriteOutboxEvent method records the change-event of the crud-task, in the outbox-table (in the task transaction)
+func (crud Crud) WriteOutboxEvent(operation string, recordIds []string, recs interface{}) error {
+	payload := map[string]interface{}{}
+	if recs != nil {
+		payload["records"] = recs
+	}
+	if len(crud.QueryParams) > 0 {
+		payload["queryParams"] = crud.QueryParams
+	}
+	eventId, err := NewId(OutboxEvent{}.IdType())
+	if err != nil {
+		return NewError("insertError", fmt.Sprintf("Outbox-event error: %v", err.Error()), err)
+	}
+	now := time.Now()
+	event := OutboxEvent{
+		ID:          eventId,
+		TableName:   crud.TableName,
+		Operation:   operation,
+		RecordIds:   strings.Join(recordIds, ","),
+		Payload:     JsonDataType{Data: payload},
+		AppId:       crud.AppParams.AppId,
+		CreatedBy:   crud.UserInfo.UserId,
+		CreatedAt:   now,
+		Status:      OutboxPending,
+		AvailableAt: now,
+	}
+	if err = crud.GormDb.Table(crud.OutboxTable).Create(&event).Error; err != nil {
+		return NewError("insertError", fmt.Sprintf("Outbox-event error: %v", err.Error()), err)
+	}
+	return nil
+}
+
+// OutboxHandler is the change-event handler; the returned error re-schedules the event delivery (retry)
+type OutboxHandler func(event OutboxEvent) error
+
+// OutboxDispatcherOptionsType provides the outbox-dispatcher options
+type OutboxDispatcherOptionsType struct {
+	BatchSize    int              // events per poll, default: 100
+	PollInterval time.Duration    // default: 1s
+	AckTimeout   time.Duration    // redelivery time of the un-acknowledged events, default: 30s
+	MaxAttempts  int              // failed status after the max delivery attempts, default: 10
+	RetryBackoff time.Duration    // initial retry-backoff (doubled per attempt), default: 1s
+	Channel      chan OutboxEvent // channel delivery (instead of the handlers), acknowledged by Ack / Nack
+}
+
+// OutboxDispatcher delivers the pending outbox-events to the registered handlers or the channel, at least once
+type OutboxDispatcher struct {
+	OutboxDispatcherOptionsType
+	Db          *gorm.DB
+	OutboxTable string
+	handlers    map[string][]OutboxHandler // key: tableName, "*" for all tables
+	mutex       sync.RWMutex
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewOutboxDispatcher constructor returns a new outbox-dispatcher, for the outbox-table (default: outbox_events)
+func NewOutboxDispatcher(db *gorm.DB, outboxTable string, options OutboxDispatcherOptionsType) *OutboxDispatcher {
+	result := &OutboxDispatcher{}
+	result.Db = db
+	result.OutboxTable = outboxTable
+	result.OutboxDispatcherOptionsType = options
+	result.handlers = map[string][]OutboxHandler{}
+	// default values
+	if result.OutboxTable == "" {
+		result.OutboxTable = DefaultOutboxTable
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = 100
+	}
+	if result.PollInterval <= 0 {
+		result.PollInterval = time.Second
+	}
+	if result.AckTimeout <= 0 {
+		result.AckTimeout = 30 * time.Second
+	}
+	if result.MaxAttempts <= 0 {
+		result.MaxAttempts = 10
+	}
+	if result.RetryBackoff <= 0 {
+		result.RetryBackoff = time.Second
+	}
+	return result
+}
+
+// RegisterHandler method adds the event-handler for the table ("" or "*" for all tables)
+func (dispatcher *OutboxDispatcher) RegisterHandler(tableName string, handler OutboxHandler) {
+	if tableName == "" {
+		tableName = "*"
+	}
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	dispatcher.handlers[tableName] = append(dispatcher.handlers[tableName], handler)
+}
+
+// Handlers method returns the event-handlers for the table, including the all-tables handlers
+func (dispatcher *OutboxDispatcher) Handlers(tableName string) []OutboxHandler {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	handlers := append([]OutboxHandler{}, dispatcher.handlers[tableName]...)
+	return append(handlers, dispatcher.handlers["*"]...)
+}
+
+// retryDelay returns the retry-backoff for the delivery attempts: RetryBackoff * 2^(attempts-1)
+func (dispatcher *OutboxDispatcher) retryDelay(attempts int) time.Duration {
+	delay := dispatcher.RetryBackoff
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+// handlerTables method returns the tables of the registered handlers, nil for all the tables ("*" handlers)
+func (dispatcher *OutboxDispatcher) handlerTables() []string {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+	if len(dispatcher.handlers["*"]) > 0 {
+		return nil
+	}
+	tables := []string{}
+	for tableName, handlers := range dispatcher.handlers {
+		if len(handlers) > 0 {
+			tables = append(tables, tableName)
+		}
+	}
+	return tables
+}
+
+// claimUpdates method returns the claim updates of the pending event: the lease for the AckTimeout, or the failed
+// status, for the event (e.g. un-acknowledged channel event) leased for the max delivery attempts
+func (dispatcher *OutboxDispatcher) claimUpdates(event OutboxEvent, now time.Time) map[string]interface{} {
+	if event.Attempts >= dispatcher.MaxAttempts {
+		return map[string]interface{}{
+			"status":     OutboxFailed,
+			"last_error": fmt.Sprintf("Delivery not acknowledged after %v attempts", event.Attempts),
+		}
+	}
+	return map[string]interface{}{
+		"attempts":     gorm.Expr("attempts + 1"),
+		"available_at": now.Add(dispatcher.AckTimeout),
+	}
+}
+
+// claimEvents method reads the next available pending events (of the tables, all if nil), and leases them for the
+// AckTimeout; the events leased for the max delivery attempts are marked as failed. The events are locked (FOR UPDATE SKIP LOCKED) on Postgres/MySQL, or claimed by the conditional
+// (pending and available) update on the other dialects, for the concurrent dispatchers.
+func (dispatcher *OutboxDispatcher) claimEvents(tables []string) ([]OutboxEvent, error) {
+	var events []OutboxEvent
+	err := dispatcher.Db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		query := tx.Table(dispatcher.OutboxTable).Where("status = ? AND available_at <= ?", OutboxPending, now)
+		if tables != nil {
+			query = query.Where("table_name IN ?", tables)
+		}
+		locking := ArrayStringContains([]string{"postgres", "mysql"}, tx.Dialector.Name())
+		if locking {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		var pendingEvents []OutboxEvent
+		if err := query.Order("created_at asc").Limit(dispatcher.BatchSize).Find(&pendingEvents).Error; err != nil {
+			return err
+		}
+		for _, event := range pendingEvents {
+			claim := tx.Table(dispatcher.OutboxTable).Where("id = ?", event.ID)
+			if !locking {
+				claim = claim.Where("status = ? AND available_at <= ?", OutboxPending, now)
+			}
+			result := claim.Updates(dispatcher.claimUpdates(event, now))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < 1 || event.Attempts >= dispatcher.MaxAttempts {
+				// claimed by another dispatcher, or failed after the max delivery attempts
+				continue
+			}
+			event.Attempts += 1
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error claiming outbox-events: %v", err.Error()))
+	}
+	return events, nil
+}
+
+// DispatchOnce method delivers the next available pending events, and returns the number of the delivered events.
+// The channel events are delivered (sent), pending the consumer's Ack / Nack.
+// The events of the tables without the registered handlers remain pending, in the handlers (non-channel) mode.
+func (dispatcher *OutboxDispatcher) DispatchOnce() (int, error) {
+	var tables []string
+	if dispatcher.Channel == nil {
+		if tables = dispatcher.handlerTables(); tables != nil && len(tables) < 1 {
+			return 0, nil
+		}
+	}
+	events, err := dispatcher.claimEvents(tables)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	for _, event := range events {
+		if dispatcher.Channel != nil {
+			select {
+			case dispatcher.Channel <- event:
+				delivered += 1
+			case <-dispatcher.stop:
+				// redelivered after the AckTimeout
+				return delivered, nil
+			}
+			continue
+		}
+		var handlerErr error
+		for _, handler := range dispatcher.Handlers(event.TableName) {
+			if handlerErr = handler(event); handlerErr != nil {
+				break
+			}
+		}
+		if handlerErr != nil {
+			if err = dispatcher.Nack(event, handlerErr); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err = dispatcher.Ack(event.ID); err != nil {
+			return delivered, err
+		}
+		delivered += 1
+	}
+	return delivered, nil
+}
+
+// Ack method marks the events as delivered
+func (dispatcher *OutboxDispatcher) Ack(ids ...string) error {
+	if len(ids) < 1 {
+		return nil
+	}
+	err := dispatcher.Db.Table(dispatcher.OutboxTable).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"status":       OutboxDelivered,
+		"delivered_at": time.Now(),
+	}).Error
+	if err != nil {
+		return errors.New(fmt.Sprintf("error acknowledging outbox-events: %v", err.Error()))
+	}
+	return nil
+}
+
+// Nack method re-schedules the event delivery with the retry-backoff, or marks the event as failed,
+// after the max delivery attempts
+func (dispatcher *OutboxDispatcher) Nack(event OutboxEvent, cause error) error {
+	updates := map[string]interface{}{
+		"available_at": time.Now().Add(dispatcher.retryDelay(event.Attempts)),
+	}
+	if cause != nil {
+		updates["last_error"] = cause.Error()
+	}
+	if event.Attempts >= dispatcher.MaxAttempts {
+		updates["status"] = OutboxFailed
+	}
+	if err := dispatcher.Db.Table(dispatcher.OutboxTable).Where("id = ?", event.ID).Updates(updates).Error; err != nil {
+		return errors.New(fmt.Sprintf("error re-scheduling outbox-event %v: %v", event.ID, err.Error()))
+	}
+	return nil
+}
+
+// Start method starts the polling dispatcher (goroutine); the dispatch errors are retried at the next poll
+func (dispatcher *OutboxDispatcher) Start() {
+	dispatcher.stop = make(chan struct{})
+	dispatcher.done = make(chan struct{})
+	go func() {
+		defer close(dispatcher.done)
+		ticker := time.NewTicker(dispatcher.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-dispatcher.stop:
+				return
+			case <-ticker.C:
+				_, _ = dispatcher.DispatchOnce()
+			}
+		}
+	}()
+}
+
+// Stop method stops the polling dispatcher, and waits for the current dispatch
+func (dispatcher *OutboxDispatcher) Stop() {
+	if dispatcher.stop == nil {
+		return
+	}
+	close(dispatcher.stop)
+	<-dispatcher.done
+	dispatcher.stop = nil
+}
@@ -0,0 +1,83 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: transactional outbox test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestOutbox(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should enable the outbox for the write-tasks only:",
+		TestFunc: func() {
+			crud := Crud{}
+			crud.Outbox = true
+			mctest.AssertEquals(t, crud.OutboxEnabled(CrudTasks().Create), true, "create outbox should be: true")
+			mctest.AssertEquals(t, crud.OutboxEnabled(CrudTasks().Delete), true, "delete outbox should be: true")
+			mctest.AssertEquals(t, crud.OutboxEnabled(CrudTasks().Read), false, "read outbox should be: false")
+			crud.Outbox = false
+			mctest.AssertEquals(t, crud.OutboxEnabled(CrudTasks().Update), false, "disabled outbox should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the event record-ids:",
+		TestFunc: func() {
+			event := OutboxEvent{RecordIds: "id-100,id-200"}
+			mctest.AssertEquals(t, len(event.Ids()), 2, "event record-ids should be: 2")
+			mctest.AssertEquals(t, event.Ids()[1], "id-200", "second record-id should be: id-200")
+			mctest.AssertEquals(t, len(OutboxEvent{}.Ids()), 0, "empty record-ids should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set the dispatcher defaults, handlers and retry-backoff:",
+		TestFunc: func() {
+			dispatcher := NewOutboxDispatcher(nil, "", OutboxDispatcherOptionsType{RetryBackoff: 100 * time.Millisecond})
+			mctest.AssertEquals(t, dispatcher.OutboxTable, DefaultOutboxTable, "outbox-table should be: outbox_events")
+			mctest.AssertEquals(t, dispatcher.BatchSize, 100, "batch-size should be: 100")
+			mctest.AssertEquals(t, dispatcher.MaxAttempts, 10, "max-attempts should be: 10")
+			dispatcher.RegisterHandler("categories", func(event OutboxEvent) error { return nil })
+			dispatcher.RegisterHandler("", func(event OutboxEvent) error { return nil })
+			mctest.AssertEquals(t, len(dispatcher.Handlers("categories")), 2, "categories handlers should be: 2")
+			mctest.AssertEquals(t, len(dispatcher.Handlers("groups")), 1, "groups handlers should be: 1")
+			mctest.AssertEquals(t, dispatcher.retryDelay(1), 100*time.Millisecond, "first retry-delay should be: 100ms")
+			mctest.AssertEquals(t, dispatcher.retryDelay(3), 400*time.Millisecond, "third retry-delay should be: 400ms")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should claim the events of the tables with the registered handlers only:",
+		TestFunc: func() {
+			dispatcher := NewOutboxDispatcher(nil, "", OutboxDispatcherOptionsType{})
+			mctest.AssertEquals(t, len(dispatcher.handlerTables()), 0, "no-handlers tables should be: 0")
+			mctest.AssertEquals(t, dispatcher.handlerTables() != nil, true, "no-handlers tables should be: not nil")
+			count, err := dispatcher.DispatchOnce()
+			mctest.AssertEquals(t, err, nil, "no-handlers dispatch error should be: nil")
+			mctest.AssertEquals(t, count, 0, "no-handlers dispatched events should be: 0")
+			dispatcher.RegisterHandler("categories", func(event OutboxEvent) error { return nil })
+			mctest.AssertEquals(t, dispatcher.handlerTables()[0], "categories", "handler table should be: categories")
+			dispatcher.RegisterHandler("*", func(event OutboxEvent) error { return nil })
+			mctest.AssertEquals(t, dispatcher.handlerTables() == nil, true, "all-tables handler tables should be: nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should fail the events leased for the max delivery attempts, instead of the lease:",
+		TestFunc: func() {
+			dispatcher := NewOutboxDispatcher(nil, "", OutboxDispatcherOptionsType{MaxAttempts: 3})
+			now := time.Now()
+			updates := dispatcher.claimUpdates(OutboxEvent{Attempts: 2}, now)
+			mctest.AssertEquals(t, updates["available_at"], now.Add(dispatcher.AckTimeout), "lease available-at should be: now + ack-timeout")
+			_, failed := updates["status"]
+			mctest.AssertEquals(t, failed, false, "leased event status should be: unchanged")
+			updates = dispatcher.claimUpdates(OutboxEvent{Attempts: 3}, now)
+			mctest.AssertEquals(t, updates["status"], OutboxFailed, "exhausted event status should be: failed")
+			_, leased := updates["available_at"]
+			mctest.AssertEquals(t, leased, false, "exhausted event should be: not leased")
+		},
+	})
+
+	mctest.PostTestResult()
+}
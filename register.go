@@ -0,0 +1,352 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud - user registration and verification methods
+
+package mcgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// MailMessageType describes the message to be delivered by the Mailer
+type MailMessageType struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer interface for message delivery (e.g. email-service), may be stubbed for testing
+type Mailer interface {
+	SendMail(message MailMessageType) error
+}
+
+// RegisterParamsType is the struct type for user registration inputs
+type RegisterParamsType struct {
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Language  string `json:"language"`
+}
+
+// RegisterResultType for Register method value (interface{}) response,
+// and to assert returned value
+type RegisterResultType struct {
+	UserId       string                     `json:"userId"`
+	VerifyExpire int64                      `json:"verifyExpire"` // in milliseconds
+	MailRes      mcresponse.ResponseMessage `json:"mailRes"`
+	LogRes       mcresponse.ResponseMessage `json:"logRes"`
+}
+
+// Register method creates a new (inactive) user account, with hashed password, and a verification code (verifyTable),
+// delivered by the Mailer
+func (crud *Crud) Register(params RegisterParamsType) mcresponse.ResponseMessage {
+	// validate params
+	errorMessages := MessageObject{}
+	if params.Username == "" {
+		errorMessages["username"] = "username is required"
+	}
+	if !govalidator.IsEmail(params.Email) {
+		errorMessages["email"] = "valid email is required"
+	}
+	if params.Password == "" {
+		errorMessages["password"] = "password is required"
+	}
+	if len(errorMessages) > 0 {
+		return GetParamsMessage(errorMessages, "paramsError")
+	}
+	if params.Language == "" {
+		params.Language = "en-US"
+	}
+	// check username and email uniqueness
+	usernameScript := fmt.Sprintf("SELECT id from %v WHERE username=$1", crud.UserTable)
+	if res := crud.checkUserExists(usernameScript, params.Username, crud.UsernameExistsMessage); res.Code != "success" {
+		return res
+	}
+	emailScript := fmt.Sprintf("SELECT id from %v WHERE email=$1", crud.UserTable)
+	if res := crud.checkUserExists(emailScript, params.Email, crud.EmailExistsMessage); res.Code != "success" {
+		return res
+	}
+
+	// create user-record (inactive, until verified) and the verification code, in a transaction
+	passwordHash, err := HashPassword(params.Password)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	code, err := GenerateToken()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	expire := time.Now().Add(time.Duration(crud.VerifyTimeout)*time.Second).UnixNano() / int64(time.Millisecond)
+	tx, err := crud.AccessDb.Begin(context.Background())
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating user-account: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// no-op, if committed
+	defer tx.Rollback(context.Background())
+	var userId string
+	userScript := fmt.Sprintf("INSERT INTO %v(username, email, password, firstname, lastname, language, is_active) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING id", crud.UserTable)
+	userRow := tx.QueryRow(context.Background(), userScript, params.Username, params.Email, passwordHash, params.Firstname, params.Lastname, params.Language, false)
+	if err = userRow.Scan(&userId); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating user-account: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	verifyScript := fmt.Sprintf("INSERT INTO %v(user_id, code, expire) VALUES($1, $2, $3)", crud.VerifyTable)
+	if _, err = tx.Exec(context.Background(), verifyScript, userId, code, expire); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating verification-information: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(context.Background()); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating user-account: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+
+	// deliver verification code
+	mailRes := crud.sendVerifyCode(params.Username, params.Email, code)
+
+	// LogCreate
+	var logRes mcresponse.ResponseMessage
+	if crud.LogCreate {
+		logRes, err = crud.TransLog.AuditLog(CrudTasks().Create, userId, AuditLogOptionsType{
+			LogRecords:  map[string]interface{}{"username": params.Username, "email": params.Email},
+			TableName:   crud.UserTable,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Registration completed successfully. Verify your account to continue.",
+		Value: RegisterResultType{
+			UserId:       userId,
+			VerifyExpire: expire,
+			MailRes:      mailRes,
+			LogRes:       logRes,
+		},
+	})
+}
+
+// checkUserExists method returns the exists response, if the user-record (e.g. by username or email) exists, the
+// readError response for the query errors, or the success response
+func (crud *Crud) checkUserExists(userScript string, value string, existsMessage string) mcresponse.ResponseMessage {
+	var existId string
+	err := crud.AccessDb.QueryRow(context.Background(), userScript, value).Scan(&existId)
+	if err == nil {
+		return mcresponse.GetResMessage("exists", mcresponse.ResponseMessageOptions{
+			Message: existsMessage,
+			Value:   nil,
+		})
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading user-information: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "User-information not found",
+		Value:   nil,
+	})
+}
+
+// sendVerifyCode method delivers the verification code, by the Mailer, and returns the delivery response
+// (empty, if no Mailer)
+func (crud *Crud) sendVerifyCode(username string, email string, code string) mcresponse.ResponseMessage {
+	var mailRes mcresponse.ResponseMessage
+	if crud.Mailer == nil {
+		return mailRes
+	}
+	err := crud.Mailer.SendMail(MailMessageType{
+		From:    crud.MsgFrom,
+		To:      email,
+		Subject: "Account verification",
+		Body:    fmt.Sprintf("Hello %v, use the following code to verify your account: %v", username, code),
+	})
+	if err != nil {
+		return mcresponse.ResponseMessage{
+			Code:    "mailError",
+			Message: fmt.Sprintf("Verification message delivery error: %v", err.Error()),
+			Value:   nil,
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Verification message delivered",
+		Value:   nil,
+	})
+}
+
+// ResendVerifyCode method replaces the (e.g. expired) verification code of the inactive user-account, by email,
+// and delivers the new code by the Mailer
+func (crud *Crud) ResendVerifyCode(email string) mcresponse.ResponseMessage {
+	if !govalidator.IsEmail(email) {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "valid email is required",
+			Value:   nil,
+		})
+	}
+	var (
+		userId   string
+		username string
+		isActive bool
+	)
+	userScript := fmt.Sprintf("SELECT id, username, is_active from %v WHERE email=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRow(context.Background(), userScript, email).Scan(&userId, &username, &isActive); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading user-information: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "User-information not found",
+			Value:   nil,
+		})
+	}
+	if isActive {
+		return mcresponse.GetResMessage("exists", mcresponse.ResponseMessageOptions{
+			Message: "User-account already verified",
+			Value:   nil,
+		})
+	}
+	code, err := GenerateToken()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	expire := time.Now().Add(time.Duration(crud.VerifyTimeout)*time.Second).UnixNano() / int64(time.Millisecond)
+	tx, err := crud.AccessDb.Begin(context.Background())
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating verification-information: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// no-op, if committed
+	defer tx.Rollback(context.Background())
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.VerifyTable)
+	verifyScript := fmt.Sprintf("INSERT INTO %v(user_id, code, expire) VALUES($1, $2, $3)", crud.VerifyTable)
+	if _, err = tx.Exec(context.Background(), delScript, userId); err == nil {
+		if _, err = tx.Exec(context.Background(), verifyScript, userId, code, expire); err == nil {
+			err = tx.Commit(context.Background())
+		}
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating verification-information: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Verification code sent. Verify your account to continue.",
+		Value: RegisterResultType{
+			UserId:       userId,
+			VerifyExpire: expire,
+			MailRes:      crud.sendVerifyCode(username, email, code),
+		},
+	})
+}
+
+// VerifyUser method activates the user account for a valid (un-expired) verification code
+func (crud *Crud) VerifyUser(code string) mcresponse.ResponseMessage {
+	if code == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Verification code is required",
+			Value:   nil,
+		})
+	}
+	var (
+		userId string
+		expire int64
+	)
+	verifyScript := fmt.Sprintf("SELECT user_id, expire from %v WHERE code=$1", crud.VerifyTable)
+	if err := crud.AccessDb.QueryRow(context.Background(), verifyScript, code).Scan(&userId, &expire); err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Verification information not found or already verified",
+			Value:   nil,
+		})
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.VerifyTable)
+	if (time.Now().Unix() * 1000) > expire {
+		// remove the expired verification code
+		_, _ = crud.AccessDb.Exec(context.Background(), delScript, userId)
+		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Verification code expired: please request a new verification code",
+			Value:   nil,
+		})
+	}
+	// activate the user account
+	userScript := fmt.Sprintf("UPDATE %v SET is_active=$1 WHERE id=$2", crud.UserTable)
+	if _, err := crud.AccessDb.Exec(context.Background(), userScript, true, userId); err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error activating user-account: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	_, _ = crud.AccessDb.Exec(context.Background(), delScript, userId)
+
+	// LogUpdate
+	var logRes mcresponse.ResponseMessage
+	var err error
+	if crud.LogUpdate {
+		logRes, err = crud.TransLog.AuditLog(CrudTasks().Update, userId, AuditLogOptionsType{
+			LogRecords:    map[string]interface{}{"id": userId, "isActive": false},
+			NewLogRecords: map[string]interface{}{"id": userId, "isActive": true},
+			TableName:     crud.UserTable,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Account verified and activated successfully.",
+		Value: CrudResultType{
+			RecordIds:   []string{userId},
+			RecordCount: 1,
+			TaskType:    CrudTasks().Update,
+			LogRes:      logRes,
+		},
+	})
+}
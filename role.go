@@ -0,0 +1,155 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud - role hierarchy / inheritance methods
+
+package mcgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+)
+
+// ExpandRoleIds returns the roleIds and their inherited (parent) roleIds, from the roleParents (roleId => parentIds) map,
+// up to maxDepth levels of inheritance. Cyclic role-inheritance is ignored.
+func ExpandRoleIds(roleIds []string, roleParents map[string][]string, maxDepth int) []string {
+	var result []string
+	visited := map[string]bool{}
+	currentIds := roleIds
+	for depth := 0; len(currentIds) > 0; depth++ {
+		var nextIds []string
+		for _, id := range currentIds {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			result = append(result, id)
+			if depth < maxDepth {
+				nextIds = append(nextIds, roleParents[id]...)
+			}
+		}
+		currentIds = nextIds
+	}
+	return result
+}
+
+// RoleParents method returns the roleId => parentIds map for the roleIds and their ancestors, up to maxRoleDepth levels
+func (crud *Crud) RoleParents(roleIds []string) (map[string][]string, error) {
+	roleParents := map[string][]string{}
+	inheritScript := fmt.Sprintf(roleInheritScript, crud.RoleInheritTable)
+	currentIds := roleIds
+	for depth := 0; depth < crud.MaxRoleDepth && len(currentIds) > 0; depth++ {
+		rows, err := crud.AccessDb.Query(context.Background(), inheritScript, currentIds, true)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("error retrieving role-inheritance information: %v", err.Error()))
+		}
+		var nextIds []string
+		for rows.Next() {
+			var roleId, parentId string
+			if err = rows.Scan(&roleId, &parentId); err != nil {
+				rows.Close()
+				return nil, errors.New(fmt.Sprintf("error parsing role-inheritance information: %v", err.Error()))
+			}
+			roleParents[roleId] = append(roleParents[roleId], parentId)
+			if _, ok := roleParents[parentId]; !ok && !ArrayStringContains(nextIds, parentId) {
+				nextIds = append(nextIds, parentId)
+			}
+		}
+		rows.Close()
+		currentIds = nextIds
+	}
+	return roleParents, nil
+}
+
+// ResolveRoleIds method returns the roleIds and all their inherited (parent) roleIds
+func (crud *Crud) ResolveRoleIds(roleIds []string) ([]string, error) {
+	roleParents, err := crud.RoleParents(roleIds)
+	if err != nil {
+		return nil, err
+	}
+	return ExpandRoleIds(roleIds, roleParents, crud.MaxRoleDepth), nil
+}
+
+// EffectivePermissions method returns the resolved (direct and inherited) permissions of the user, for the tableName
+func (crud *Crud) EffectivePermissions(userId string, tableName string) mcresponse.ResponseMessage {
+	if userId == "" || tableName == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId and tableName are required",
+			Value:   nil,
+		})
+	}
+	var (
+		roleIds   []string
+		isAdmin   bool
+		serviceId string
+	)
+	userScript := fmt.Sprintf(userRolesScript, crud.UserTable)
+	if err := crud.AccessDb.QueryRow(context.Background(), userScript, userId).Scan(&roleIds, &isAdmin); err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("User information not found | %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	serviceScript := fmt.Sprintf(serviceIdScript, crud.ServiceTable)
+	if err := crud.AccessDb.QueryRow(context.Background(), serviceScript, tableName).Scan(&serviceId); err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Service/table information not found | %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	allRoleIds, err := crud.ResolveRoleIds(roleIds)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	var inheritedRoleIds []string
+	for _, id := range allRoleIds {
+		if !ArrayStringContains(roleIds, id) {
+			inheritedRoleIds = append(inheritedRoleIds, id)
+		}
+	}
+	roleServices, err := crud.GetRoleServices(crud.AccessDb, crud.RoleTable, allRoleIds, []string{serviceId})
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	permission := EffectivePermissionType{
+		UserId:           userId,
+		TableName:        tableName,
+		ServiceId:        serviceId,
+		IsAdmin:          isAdmin,
+		RoleIds:          roleIds,
+		InheritedRoleIds: inheritedRoleIds,
+		RoleServices:     roleServices,
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Effective permissions computed successfully.",
+		Value:   MergeRolePermissions(permission),
+	})
+}
+
+// MergeRolePermissions function merges the role-services permissions into the Can* flags of the effective permissions;
+// all the tasks are permitted to the admin, as by the TaskPermission method
+func MergeRolePermissions(permission EffectivePermissionType) EffectivePermissionType {
+	if permission.IsAdmin {
+		permission.CanRead = true
+		permission.CanCreate = true
+		permission.CanUpdate = true
+		permission.CanDelete = true
+		permission.CanCrud = true
+		return permission
+	}
+	for _, rs := range permission.RoleServices {
+		permission.CanRead = permission.CanRead || rs.CanRead || rs.CanCrud
+		permission.CanCreate = permission.CanCreate || rs.CanCreate || rs.CanCrud
+		permission.CanUpdate = permission.CanUpdate || rs.CanUpdate || rs.CanCrud
+		permission.CanDelete = permission.CanDelete || rs.CanDelete || rs.CanCrud
+		permission.CanCrud = permission.CanCrud || rs.CanCrud
+	}
+	return permission
+}
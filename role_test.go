@@ -0,0 +1,85 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: role hierarchy / inheritance test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"strings"
+	"testing"
+)
+
+func TestExpandRoleIds(t *testing.T) {
+	roleParents := map[string][]string{
+		"editor":  {"author"},
+		"author":  {"reader"},
+		"reader":  {"guest"},
+		"manager": {"editor", "author"},
+		"cycleA":  {"cycleB"},
+		"cycleB":  {"cycleA"},
+	}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should include the inherited roles, by hierarchy-level:",
+		TestFunc: func() {
+			res := ExpandRoleIds([]string{"editor"}, roleParents, 10)
+			mctest.AssertEquals(t, strings.Join(res, ","), "editor,author,reader,guest", "expanded roles should be: editor,author,reader,guest")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should include the shared parent-roles once:",
+		TestFunc: func() {
+			res := ExpandRoleIds([]string{"manager"}, roleParents, 10)
+			mctest.AssertEquals(t, strings.Join(res, ","), "manager,editor,author,reader,guest", "expanded roles should be: manager,editor,author,reader,guest")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should stop at the max-depth:",
+		TestFunc: func() {
+			res := ExpandRoleIds([]string{"editor"}, roleParents, 1)
+			mctest.AssertEquals(t, strings.Join(res, ","), "editor,author", "expanded roles should be: editor,author")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should ignore cyclic role-inheritance:",
+		TestFunc: func() {
+			res := ExpandRoleIds([]string{"cycleA"}, roleParents, 10)
+			mctest.AssertEquals(t, strings.Join(res, ","), "cycleA,cycleB", "expanded roles should be: cycleA,cycleB")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should merge the role-services permissions, and permit all the tasks to the admin:",
+		TestFunc: func() {
+			roleServices := []RoleServiceType{{CanRead: true}, {CanUpdate: true}}
+			res := MergeRolePermissions(EffectivePermissionType{RoleServices: roleServices})
+			mctest.AssertEquals(t, res.CanRead && res.CanUpdate, true, "read and update permissions should be: true")
+			mctest.AssertEquals(t, res.CanCreate || res.CanDelete || res.CanCrud, false, "create, delete and crud permissions should be: false")
+			res = MergeRolePermissions(EffectivePermissionType{IsAdmin: true})
+			mctest.AssertEquals(t, res.CanRead && res.CanCreate && res.CanUpdate && res.CanDelete && res.CanCrud, true, "admin permissions should be: true")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should merge the child and the inherited (parent) role-services permissions:",
+		TestFunc: func() {
+			roleServices := []RoleServiceType{
+				{RoleId: "editor", CanUpdate: true},
+				{RoleId: "author", CanCreate: true},
+				{RoleId: "reader", CanRead: true},
+				{RoleId: "manager", CanDelete: true},
+			}
+			// role-services of the expanded roleIds, as by the GetRoleServices (role_id = ANY($2)) query
+			roleIds := ExpandRoleIds([]string{"editor"}, roleParents, 10)
+			var permittedServices []RoleServiceType
+			for _, rs := range roleServices {
+				if ArrayStringContains(roleIds, rs.RoleId) {
+					permittedServices = append(permittedServices, rs)
+				}
+			}
+			res := MergeRolePermissions(EffectivePermissionType{RoleIds: []string{"editor"}, RoleServices: permittedServices})
+			mctest.AssertEquals(t, res.CanRead && res.CanCreate && res.CanUpdate, true, "read, create and update permissions should be: true")
+			mctest.AssertEquals(t, res.CanDelete || res.CanCrud, false, "delete and crud permissions should be: false")
+		},
+	})
+
+	mctest.PostTestResult()
+}
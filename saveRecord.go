@@ -13,21 +13,42 @@ import (
 )
 
 func (crud Crud) Create(rec interface{}) mcresponse.ResponseMessage {
-	result := crud.GormDb.Create(&rec)
-	if result.Error != nil {
-		return mcresponse.GetResMessage("insertError",
+	// set the new record-id, by the model or crud id-type
+	idRec, err := SetRecordIds(rec, crud.IdType)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError",
 			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
+				Message: fmt.Sprintf("%v", err.Error()),
 				Value:   nil,
 			})
 	}
+	rec = idRec
+	// stamp the app/tenant-id on the new record
+	if crud.AppParams.AppId != "" {
+		appRec, err := SetRecordsField(rec, "AppId", crud.AppParams.AppId, false)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", err.Error()),
+					Value:   nil,
+				})
+		}
+		rec = appRec
+	}
+	result := crud.GormDb.Create(&rec)
+	if result.Error != nil {
+		return crud.DbErrorMessage(result.Error, "insertError")
+	}
 	// LogCreate
 	var logRes mcresponse.ResponseMessage
-	var err error
 	if crud.LogCreate {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Create, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: rec,
-			TableName:  crud.TableName,
+			LogRecords:  rec,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   RecordIdsFrom(rec),
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -37,12 +58,19 @@ func (crud Crud) Create(rec interface{}) mcresponse.ResponseMessage {
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Create, RecordIdsFrom(rec))
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
+				RecordIds:   RecordIdsFrom(rec),
 				RecordCount: int(result.RowsAffected),
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
@@ -53,21 +81,57 @@ func (crud Crud) CreateBatch(recs interface{}, batch int) mcresponse.ResponseMes
 	if batch == 0 {
 		batch = 10000
 	}
-	result := crud.GormDb.CreateInBatches(&recs, batch)
-	if result.Error != nil {
-		return mcresponse.GetResMessage("insertError",
+	// set the new record-ids, by the model or crud id-type
+	idRecs, err := SetRecordIds(recs, crud.IdType)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError",
 			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
+				Message: fmt.Sprintf("%v", err.Error()),
 				Value:   nil,
 			})
 	}
+	recs = idRecs
+	// stamp the app/tenant-id on the new records
+	if crud.AppParams.AppId != "" {
+		appRecs, err := SetRecordsField(recs, "AppId", crud.AppParams.AppId, false)
+		if err != nil {
+			return mcresponse.GetResMessage("paramsError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", err.Error()),
+					Value:   nil,
+				})
+		}
+		recs = appRecs
+	}
+	var result *gorm.DB
+	if crud.TreeEnabled() {
+		// tree-table: insert the new nodes and compute their materialized paths, in a transaction
+		err = crud.GormDb.Transaction(func(tx *gorm.DB) error {
+			result = tx.CreateInBatches(&recs, batch)
+			if result.Error != nil {
+				return result.Error
+			}
+			txCrud := crud
+			txCrud.GormDb = tx
+			return txCrud.UpdateTreePaths(RecordIdsFrom(recs))
+		})
+	} else {
+		result = crud.GormDb.CreateInBatches(&recs, batch)
+		err = result.Error
+	}
+	if err != nil {
+		return crud.DbErrorMessage(err, "insertError")
+	}
 	// LogCreate
 	var logRes mcresponse.ResponseMessage
-	var err error
 	if crud.LogCreate {
 		logRes, err = crud.TransLog.AuditLog(CrudTasks().Create, crud.UserInfo.UserId, AuditLogOptionsType{
-			LogRecords: recs,
-			TableName:  crud.TableName,
+			LogRecords:  recs,
+			TableName:   crud.TableName,
+			AppId:       crud.AppParams.AppId,
+			RequestMeta: crud.RequestMeta,
+			QueryParams: crud.QueryParams,
+			RecordIds:   RecordIdsFrom(recs),
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -77,18 +141,35 @@ func (crud Crud) CreateBatch(recs interface{}, batch int) mcresponse.ResponseMes
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Create, RecordIdsFrom(recs))
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
+				RecordIds:   RecordIdsFrom(recs),
 				RecordCount: int(result.RowsAffected),
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
 }
 
 func (crud Crud) UpdateById(model interface{}, rec interface{}, id string) mcresponse.ResponseMessage {
+	// strict not-found mode: notFound response for the missing record
+	if crud.StrictNotFound {
+		missingIds, mErr := crud.MissingRecordIds([]string{id})
+		if mErr != nil {
+			return crud.DbErrorMessage(mErr, "readError")
+		}
+		if len(missingIds) > 0 {
+			return crud.NotFoundMessage(missingIds)
+		}
+	}
 	var getRes mcresponse.ResponseMessage
 	if crud.LogUpdate {
 		// get current records
@@ -103,21 +184,11 @@ func (crud Crud) UpdateById(model interface{}, rec interface{}, id string) mcres
 				Value:   nil,
 			})
 	}
-	// destruct id and other-fields from update-record
-	upRec := map[string]interface{}{}
-	for k, v := range mapRec {
-		if k == "id" {
-			continue
-		}
-		upRec[k] = v
-	}
-	result := crud.GormDb.Model(&model).Where("id = ?", id).Updates(upRec)
+	// destruct id and other-fields from update-record (mapRec)
+	_, upRec := crud.updateRecordMap(mapRec)
+	result := crud.GormDb.Scopes(crud.AppScope).Model(&model).Where("id = ?", id).Updates(upRec)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("updateError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "updateError")
 	}
 	// LogUpdate
 	var logRes mcresponse.ResponseMessage
@@ -126,6 +197,12 @@ func (crud Crud) UpdateById(model interface{}, rec interface{}, id string) mcres
 			LogRecords:    getRes.Value,
 			NewLogRecords: map[string]interface{}{"id": []string{id}, "record": rec},
 			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			QueryParams:   crud.QueryParams,
+			RecordIds:     []string{id},
+			LogDiffs:      ComputeRecordDiffs(getRes.Value, rec),
+			DiffOnly:      crud.LogDiffOnly,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -135,12 +212,18 @@ func (crud Crud) UpdateById(model interface{}, rec interface{}, id string) mcres
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Update, []string{id})
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				RecordCount: int(result.RowsAffected),
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
@@ -154,6 +237,15 @@ func (crud Crud) UpdateByIds(model interface{}, rec interface{}) mcresponse.Resp
 				Value:   nil,
 			})
 	}
+	// strict not-found mode: report the missing records
+	var missingIds []string
+	if crud.StrictNotFound {
+		var mErr error
+		missingIds, mErr = crud.MissingRecordIds(crud.RecordIds)
+		if mErr != nil {
+			return crud.DbErrorMessage(mErr, "readError")
+		}
+	}
 	var getRes mcresponse.ResponseMessage
 	if crud.LogUpdate {
 		// get current records
@@ -169,20 +261,10 @@ func (crud Crud) UpdateByIds(model interface{}, rec interface{}) mcresponse.Resp
 			})
 	}
 	// destruct id and other-fields from update-record (mapRec)
-	upRec := map[string]interface{}{}
-	for k, v := range mapRec {
-		if k == "id" {
-			continue
-		}
-		upRec[k] = v
-	}
-	result := crud.GormDb.Model(&model).Where("id in ?", crud.RecordIds).Updates(upRec)
+	_, upRec := crud.updateRecordMap(mapRec)
+	result := crud.GormDb.Scopes(crud.AppScope).Model(&model).Where("id in ?", crud.RecordIds).Updates(upRec)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("updateError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "updateError")
 	}
 	// LogUpdate
 	var logRes mcresponse.ResponseMessage
@@ -191,6 +273,12 @@ func (crud Crud) UpdateByIds(model interface{}, rec interface{}) mcresponse.Resp
 			LogRecords:    getRes.Value,
 			NewLogRecords: map[string]interface{}{"id": crud.RecordIds, "record": rec},
 			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			QueryParams:   crud.QueryParams,
+			RecordIds:     crud.RecordIds,
+			LogDiffs:      ComputeRecordDiffs(getRes.Value, rec),
+			DiffOnly:      crud.LogDiffOnly,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -200,12 +288,19 @@ func (crud Crud) UpdateByIds(model interface{}, rec interface{}) mcresponse.Resp
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Update, crud.RecordIds)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				RecordCount: int(result.RowsAffected),
+				MissingIds:  missingIds,
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
@@ -263,20 +358,21 @@ func (crud Crud) UpdateByParam(model interface{}, rec interface{}) mcresponse.Re
 			})
 	}
 	// destruct id and other-fields from update-record (mapRec)
-	upRec := map[string]interface{}{}
-	for k, v := range mapRec {
-		if k == "id" {
-			continue
+	_, upRec := crud.updateRecordMap(mapRec)
+	// matching record-ids, for the record history
+	var historyIds []string
+	if crud.HistoryEnabled() {
+		if idRes := crud.GormDb.Scopes(crud.AppScope).Model(&model).Where(qString, qValues...).Pluck("id", &historyIds); idRes.Error != nil {
+			return mcresponse.GetResMessage("readError",
+				mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("%v", idRes.Error.Error()),
+					Value:   nil,
+				})
 		}
-		upRec[k] = v
 	}
-	result := crud.GormDb.Model(&model).Where(qString, qValues...).Updates(upRec)
+	result := crud.GormDb.Scopes(crud.AppScope).Model(&model).Where(qString, qValues...).Updates(upRec)
 	if result.Error != nil {
-		return mcresponse.GetResMessage("updateError",
-			mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("%v", result.Error.Error()),
-				Value:   nil,
-			})
+		return crud.DbErrorMessage(result.Error, "updateError")
 	}
 	// LogUpdate
 	var logRes mcresponse.ResponseMessage
@@ -285,6 +381,12 @@ func (crud Crud) UpdateByParam(model interface{}, rec interface{}) mcresponse.Re
 			LogRecords:    getRes.Value,
 			NewLogRecords: map[string]interface{}{"queryParams": crud.QueryParams, "record": rec},
 			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			QueryParams:   crud.QueryParams,
+			RecordIds:     RecordIdsFrom(getRes.Value),
+			LogDiffs:      ComputeRecordDiffs(getRes.Value, rec),
+			DiffOnly:      crud.LogDiffOnly,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -294,17 +396,42 @@ func (crud Crud) UpdateByParam(model interface{}, rec interface{}) mcresponse.Re
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Update, historyIds)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				RecordCount: int(result.RowsAffected),
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
 }
 
+// updateRecordMap method returns the record-id and the update-fields of the update-record (mapRec), without the id,
+// and with the app/tenant-id (app_id) of the current app/tenant, if specified, i.e. the records cannot be moved to
+// another app/tenant
+func (crud Crud) updateRecordMap(mapRec map[string]interface{}) (string, map[string]interface{}) {
+	var id string
+	upRec := map[string]interface{}{}
+	for k, v := range mapRec {
+		if k == "id" {
+			id, _ = v.(string)
+			continue
+		}
+		if k == "app_id" && crud.AppParams.AppId != "" {
+			v = crud.AppParams.AppId
+		}
+		upRec[k] = v
+	}
+	return id, upRec
+}
+
 // Update method, for multiple records-update
 func (crud Crud) Update(model interface{}, recs interface{}) mcresponse.ResponseMessage {
 	// validate recs as slice of interface/records(struct/map)
@@ -363,6 +490,7 @@ func (crud Crud) Update(model interface{}, recs interface{}) mcresponse.Response
 	// perform multiple updates | TODO: transactional
 	var result *gorm.DB
 	resultCount := 0
+	var updatedIds []string
 	for _, record := range recs.([]interface{}) {
 		// convert struct to map to save all fields (including zero-value fields)
 		mapRec, err := StructToCaseUnderscoreMap(record)
@@ -374,25 +502,13 @@ func (crud Crud) Update(model interface{}, recs interface{}) mcresponse.Response
 				})
 		}
 		// destruct/exclude id from update-record (mapRec)
-		var id string
-		upRec := map[string]interface{}{}
-		for k, v := range mapRec {
-			if k == "id" {
-				idVal, _ := v.(string)
-				id = idVal
-				continue
-			}
-			upRec[k] = v
-		}
-		result = crud.GormDb.Model(&model).Where("id = ?", id).Updates(upRec)
+		id, upRec := crud.updateRecordMap(mapRec)
+		result = crud.GormDb.Scopes(crud.AppScope).Model(&model).Where("id = ?", id).Updates(upRec)
 		if result.Error != nil {
-			return mcresponse.GetResMessage("updateError",
-				mcresponse.ResponseMessageOptions{
-					Message: fmt.Sprintf("%v", result.Error.Error()),
-					Value:   nil,
-				})
+			return crud.DbErrorMessage(result.Error, "updateError")
 		}
 		resultCount++
+		updatedIds = append(updatedIds, id)
 	}
 	// LogUpdate
 	var logRes mcresponse.ResponseMessage
@@ -402,6 +518,12 @@ func (crud Crud) Update(model interface{}, recs interface{}) mcresponse.Response
 			LogRecords:    getRes.Value,
 			NewLogRecords: recs,
 			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			QueryParams:   crud.QueryParams,
+			RecordIds:     crud.RecordIds,
+			LogDiffs:      ComputeRecordDiffs(getRes.Value, recs),
+			DiffOnly:      crud.LogDiffOnly,
 		})
 		if err != nil {
 			logRes = mcresponse.ResponseMessage{
@@ -411,12 +533,18 @@ func (crud Crud) Update(model interface{}, recs interface{}) mcresponse.Response
 			}
 		}
 	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Update, updatedIds)
+	}
 	return mcresponse.GetResMessage("success",
 		mcresponse.ResponseMessageOptions{
 			Message: "Task completed successfully",
 			Value: CrudResultType{
 				RecordCount: resultCount,
 				LogRes:      logRes,
+				HistoryRes:  historyRes,
 				TaskType:    crud.TaskType,
 			},
 		})
@@ -0,0 +1,31 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: app/tenant scoping test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestTenantUpdate(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should keep the current app/tenant-id, for the update-record app_id changes:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: GroupTable, AppParams: AppParamsType{AppId: "app-100"}}, CrudOptionsType{})
+			mapRec, err := StructToCaseUnderscoreMap(Group{BaseModelType: BaseModelType{ID: "g1", AppId: "app-200"}, Name: "services"})
+			mctest.AssertEquals(t, err, nil, "struct-map error should be: nil")
+			id, upRec := crud.updateRecordMap(mapRec)
+			mctest.AssertEquals(t, id, "g1", "update-record id should be: g1")
+			_, hasId := upRec["id"]
+			mctest.AssertEquals(t, hasId, false, "update-fields id should be: excluded")
+			mctest.AssertEquals(t, upRec["app_id"], "app-100", "update-fields app_id should be: app-100")
+			mapRec["app_id"] = ""
+			_, upRec = crud.updateRecordMap(mapRec)
+			mctest.AssertEquals(t, upRec["app_id"], "app-100", "blank update-fields app_id should be: app-100")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,531 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - tree/hierarchy operations, for the self-referencing (parent-id) tables
+
+package mcgorm
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"gorm.io/gorm"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// TreeOptionsType describes the self-referencing (tree) table fields/columns and the delete-mode
+type TreeOptionsType struct {
+	ParentField   string // parent-id column, default: parent_id
+	PathField     string // materialized-path column, e.g. path, none (no path maintenance) if not set
+	PathKeyField  string // path-segment column of the node, default: id
+	PathSeparator string // default: /
+	DeleteMode    string // TreeDeleteBlock (default) | TreeDeleteCascade
+	MaxDepth      int    // maximum recursive depth (ancestors/descendants), default: 100
+}
+
+// tree delete-modes
+const (
+	TreeDeleteBlock   = "block"   // subItems response, for the nodes with children
+	TreeDeleteCascade = "cascade" // delete the nodes and all the descendants (in one delete statement)
+)
+
+// tree query directions
+const (
+	TreeDescendants = "descendants"
+	TreeAncestors   = "ancestors"
+)
+
+// TreeDepthField is the recursive depth (from the node: 1 for the children/parent) of the tree query records
+const TreeDepthField = "tree_depth"
+
+// identifiers (table/column names) composed into the recursive tree queries
+var treeIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// TreeEnabled returns true, if the tree options are set for the crud-table
+func (crud Crud) TreeEnabled() bool {
+	_, ok := crud.TreeTables[crud.TableName]
+	return ok
+}
+
+// TreeOptions returns the tree options of the crud-table, with the default values
+func (crud Crud) TreeOptions() TreeOptionsType {
+	options := crud.TreeTables[crud.TableName]
+	// default values
+	if options.ParentField == "" {
+		options.ParentField = "parent_id"
+	}
+	if options.PathKeyField == "" {
+		options.PathKeyField = "id"
+	}
+	if options.PathSeparator == "" {
+		options.PathSeparator = "/"
+	}
+	if options.DeleteMode == "" {
+		options.DeleteMode = TreeDeleteBlock
+	}
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = 100
+	}
+	return options
+}
+
+// ComposeTreePath returns the materialized path of the node, i.e. the parent path and the node path-key,
+// e.g. /books/fiction. The path of a root node (no parent path) is the separator and the node path-key.
+func ComposeTreePath(parentPath string, pathKey string, separator string) string {
+	return strings.TrimSuffix(parentPath, separator) + separator + pathKey
+}
+
+// treeValue returns the string value of the tree field (id, parent-id, path), or "" for the nil values
+func treeValue(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	case *string:
+		if val == nil {
+			return ""
+		}
+		return *val
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// TreeQuery method composes the recursive (CTE) query and the query values, for the descendants (depth order) or the
+// ancestors (root first) of the node, for the current app/tenant. Supported: Postgres, SQLite and MySQL 8.
+func (crud Crud) TreeQuery(direction string, id string) (string, []interface{}, error) {
+	options := crud.TreeOptions()
+	for _, identifier := range []string{crud.TableName, options.ParentField} {
+		if !treeIdentifierRegexp.MatchString(identifier) {
+			return "", nil, errors.New(fmt.Sprintf("invalid tree table/field name: %v", identifier))
+		}
+	}
+	table := crud.TableName
+	parent := options.ParentField
+	var anchorQuery, recursiveQuery, order string
+	switch direction {
+	case TreeDescendants:
+		anchorQuery = fmt.Sprintf("SELECT t.*, 1 AS %v FROM %v t WHERE t.%v = ?", TreeDepthField, table, parent)
+		recursiveQuery = fmt.Sprintf("SELECT t.*, tn.%v + 1 FROM %v t INNER JOIN tree_nodes tn ON t.%v = tn.id WHERE tn.%v < ?", TreeDepthField, table, parent, TreeDepthField)
+		order = "ASC"
+	case TreeAncestors:
+		anchorQuery = fmt.Sprintf("SELECT t.*, 1 AS %v FROM %v t INNER JOIN %v n ON t.id = n.%v WHERE n.id = ?", TreeDepthField, table, table, parent)
+		recursiveQuery = fmt.Sprintf("SELECT t.*, tn.%v + 1 FROM %v t INNER JOIN tree_nodes tn ON t.id = tn.%v WHERE tn.%v < ?", TreeDepthField, table, parent, TreeDepthField)
+		order = "DESC"
+	default:
+		return "", nil, errors.New(fmt.Sprintf("invalid tree direction: %v", direction))
+	}
+	anchorValues := []interface{}{id}
+	recursiveValues := []interface{}{options.MaxDepth}
+	if crud.AppParams.AppId != "" {
+		anchorQuery += " AND t.app_id = ?"
+		recursiveQuery += " AND t.app_id = ?"
+		anchorValues = append(anchorValues, crud.AppParams.AppId)
+		recursiveValues = append(recursiveValues, crud.AppParams.AppId)
+	}
+	query := fmt.Sprintf("WITH RECURSIVE tree_nodes AS (%v UNION ALL %v) SELECT * FROM tree_nodes ORDER BY %v %v",
+		anchorQuery, recursiveQuery, TreeDepthField, order)
+	return query, append(anchorValues, recursiveValues...), nil
+}
+
+// TreeNodes method returns the descendants or the ancestors of the node, as the table-records (table-column keys)
+func (crud Crud) TreeNodes(direction string, id string) ([]map[string]interface{}, error) {
+	query, values, err := crud.TreeQuery(direction, id)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []map[string]interface{}
+	if err = crud.GormDb.Raw(query, values...).Scan(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// TreeNodeIds method returns the record-ids of the descendants or the ancestors of the node
+func (crud Crud) TreeNodeIds(direction string, id string) ([]string, error) {
+	nodes, err := crud.TreeNodes(direction, id)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, node := range nodes {
+		ids = append(ids, treeValue(node["id"]))
+	}
+	return ids, nil
+}
+
+// getTreeNodes method returns the descendants or the ancestors response of the node
+func (crud Crud) getTreeNodes(direction string, id string) mcresponse.ResponseMessage {
+	missingIds, err := crud.MissingRecordIds([]string{id})
+	if err != nil {
+		return crud.DbErrorMessage(err, "readError")
+	}
+	if len(missingIds) > 0 {
+		return crud.NotFoundMessage(missingIds)
+	}
+	nodes, err := crud.TreeNodes(direction, id)
+	if err != nil {
+		return crud.DbErrorMessage(err, "readError")
+	}
+	var records []interface{}
+	for _, node := range nodes {
+		records = append(records, node)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Task completed successfully",
+		Value: GetResultType{
+			Records: records,
+			Stats: GetStatType{
+				RecordsCount: len(records),
+				RecordIds:    []string{id},
+			},
+			TaskType: CrudTasks().Read,
+		},
+	})
+}
+
+// GetDescendants method returns the descendants of the node (table-column keys, with the tree_depth), in depth order
+func (crud Crud) GetDescendants(id string) mcresponse.ResponseMessage {
+	return crud.getTreeNodes(TreeDescendants, id)
+}
+
+// GetAncestors method returns the ancestors of the node (table-column keys, with the tree_depth), root first
+func (crud Crud) GetAncestors(id string) mcresponse.ResponseMessage {
+	return crud.getTreeNodes(TreeAncestors, id)
+}
+
+// CheckTreeCycle method returns the paramsError (ErrorType), if the parentId is the node or one of its descendants,
+// i.e. the node is one of the ancestors of the parentId, walked up to the root node (not limited by the MaxDepth)
+func (crud Crud) CheckTreeCycle(id string, parentId string) error {
+	return checkTreeCycle(id, parentId, crud.treeParentId)
+}
+
+// checkTreeCycle function walks up the ancestors of the parentId, by the parentOf lookup, until the root node or an
+// already visited node (an existing cycle), and returns the paramsError (ErrorType) if the node (id) is an ancestor
+func checkTreeCycle(id string, parentId string, parentOf func(nodeId string) (string, error)) error {
+	if parentId == "" {
+		return nil
+	}
+	if parentId == id {
+		return NewError("paramsError", fmt.Sprintf("Tree-cycle error: record %v cannot be its own parent", id), nil)
+	}
+	visitedIds := map[string]bool{}
+	nodeId := parentId
+	for nodeId != "" && !visitedIds[nodeId] {
+		if nodeId == id {
+			return NewError("paramsError", fmt.Sprintf("Tree-cycle error: parent %v is a descendant of record %v", parentId, id), nil)
+		}
+		visitedIds[nodeId] = true
+		nextId, err := parentOf(nodeId)
+		if err != nil {
+			return DbError(err, "readError")
+		}
+		nodeId = nextId
+	}
+	return nil
+}
+
+// treeParentId method returns the parent-id of the node, or "" for the root node or the missing node
+func (crud Crud) treeParentId(id string) (string, error) {
+	var parentIds []sql.NullString
+	err := crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", id).Pluck(crud.TreeOptions().ParentField, &parentIds).Error
+	if err != nil {
+		return "", err
+	}
+	if len(parentIds) < 1 || !parentIds[0].Valid {
+		return "", nil
+	}
+	return parentIds[0].String, nil
+}
+
+// CheckTreeUpdate method checks the new parents of the update record(s) for the tree-cycles, and returns the
+// updated node-ids: the record's own id, or the updateIds (by recordIds or queryParams)
+func (crud Crud) CheckTreeUpdate(modelRef interface{}, recs interface{}, updateIds []string) ([]string, mcresponse.ResponseMessage) {
+	stmt := &gorm.Statement{DB: crud.GormDb}
+	if err := stmt.Parse(modelRef); err != nil {
+		return nil, mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("%v", err.Error()),
+			Value:   nil,
+		})
+	}
+	options := crud.TreeOptions()
+	parentField := stmt.Schema.LookUpField(options.ParentField)
+	if parentField == nil {
+		return nil, mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Tree parent-field %v not found in table %v", options.ParentField, crud.TableName),
+			Value:   nil,
+		})
+	}
+	var nodeIds []string
+	for _, record := range recordValues(recs) {
+		ids := updateIds
+		if stmt.Schema.PrioritizedPrimaryField != nil {
+			if id, idZero := stmt.Schema.PrioritizedPrimaryField.ValueOf(record); !idZero {
+				ids = []string{fmt.Sprintf("%v", id)}
+			}
+		}
+		// the update saves all the fields: the zero/nil parent value is the root node
+		var parentId string
+		if value, isZero := parentField.ValueOf(record); !isZero {
+			if ptrValue := reflect.ValueOf(value); ptrValue.Kind() == reflect.Ptr {
+				if !ptrValue.IsNil() {
+					parentId = treeValue(ptrValue.Elem().Interface())
+				}
+			} else {
+				parentId = treeValue(value)
+			}
+		}
+		for _, id := range ids {
+			if err := crud.CheckTreeCycle(id, parentId); err != nil {
+				return nil, crud.ErrorResponse(err)
+			}
+			if !ArrayStringContains(nodeIds, id) {
+				nodeIds = append(nodeIds, id)
+			}
+		}
+	}
+	return nodeIds, mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Tree check completed successfully",
+		Value:   nodeIds,
+	})
+}
+
+// UpdateTreePaths method recomputes the materialized paths of the nodes and their descendants, from the parent
+// paths, and saves the changed paths. No-op, if the PathField is not set.
+func (crud Crud) UpdateTreePaths(ids []string) error {
+	options := crud.TreeOptions()
+	if options.PathField == "" || len(ids) < 1 {
+		return nil
+	}
+	return crud.GormDb.Transaction(func(tx *gorm.DB) error {
+		txCrud := crud
+		txCrud.GormDb = tx
+		for _, id := range ids {
+			var nodes []map[string]interface{}
+			if err := tx.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", id).Find(&nodes).Error; err != nil {
+				return err
+			}
+			if len(nodes) < 1 {
+				continue
+			}
+			parentPath := ""
+			if parentId := treeValue(nodes[0][options.ParentField]); parentId != "" {
+				var parents []map[string]interface{}
+				if err := tx.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", parentId).Find(&parents).Error; err != nil {
+					return err
+				}
+				if len(parents) > 0 {
+					parentPath = treeValue(parents[0][options.PathField])
+				}
+			}
+			descendants, err := txCrud.TreeNodes(TreeDescendants, id)
+			if err != nil {
+				return err
+			}
+			// descendants in depth order: the parent path is computed before the child path
+			nodes = append(nodes[:1], descendants...)
+			paths := map[string]string{}
+			for index, node := range nodes {
+				nodeId := treeValue(node["id"])
+				nodeParentPath := parentPath
+				if index > 0 {
+					nodeParentPath = paths[treeValue(node[options.ParentField])]
+				}
+				paths[nodeId] = ComposeTreePath(nodeParentPath, treeValue(node[options.PathKeyField]), options.PathSeparator)
+				if treeValue(node[options.PathField]) == paths[nodeId] {
+					continue
+				}
+				if err = tx.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", nodeId).Update(options.PathField, paths[nodeId]).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// MoveSubtree method moves the node (and its descendants) to the new parent (root node, if parentId is ""), with the
+// app/tenant access and update task-permission checks and the update hooks (and outbox change-event) of the table
+func (crud *Crud) MoveSubtree(modelRef interface{}, id string, parentId string) mcresponse.ResponseMessage {
+	if accessRes := crud.taskAccess(CrudTasks().Update); accessRes.Code != "success" {
+		return accessRes
+	}
+	moveCrud := *crud
+	moveCrud.TaskType = CrudTasks().Update
+	moveCrud.RecordIds = []string{id}
+	moveCrud.QueryParams = nil
+	var parentValue interface{}
+	if parentId != "" {
+		parentValue = parentId
+	}
+	moveRecs := []interface{}{map[string]interface{}{"id": id, crud.TreeOptions().ParentField: parentValue}}
+	return moveCrud.RunWithHooks(CrudTasks().Update, modelRef, moveRecs, func(taskCrud *Crud, taskRecs interface{}) mcresponse.ResponseMessage {
+		// move-record (id and new parent), as set by the before-hooks
+		moveId, moveParentId := treeMoveRecord(taskRecs, crud.TreeOptions().ParentField, id, parentId)
+		taskCrud.RecordIds = []string{moveId}
+		return taskCrud.moveSubtree(modelRef, moveId, moveParentId)
+	})
+}
+
+// treeMoveRecord function returns the node id and the new parent-id of the move-record (taskRecs), or the id and
+// parentId, if the move-record is not set
+func treeMoveRecord(taskRecs interface{}, parentField string, id string, parentId string) (string, string) {
+	recs, ok := taskRecs.([]interface{})
+	if !ok || len(recs) != 1 {
+		return id, parentId
+	}
+	rec, ok := recs[0].(map[string]interface{})
+	if !ok {
+		return id, parentId
+	}
+	if recId := treeValue(rec["id"]); recId != "" {
+		id = recId
+	}
+	if parentValue, ok := rec[parentField]; ok {
+		parentId = treeValue(parentValue)
+	}
+	return id, parentId
+}
+
+// moveSubtree method moves the node (and its descendants) to the new parent, after the tree-cycle check, and
+// recomputes the materialized paths of the subtree, in a transaction
+func (crud *Crud) moveSubtree(modelRef interface{}, id string, parentId string) mcresponse.ResponseMessage {
+	if !crud.TreeEnabled() {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Tree options not set for table %v", crud.TableName),
+			Value:   nil,
+		})
+	}
+	options := crud.TreeOptions()
+	checkIds := []string{id}
+	if parentId != "" {
+		checkIds = append(checkIds, parentId)
+	}
+	missingIds, err := crud.MissingRecordIds(checkIds)
+	if err != nil {
+		return crud.DbErrorMessage(err, "readError")
+	}
+	if len(missingIds) > 0 {
+		return crud.NotFoundMessage(missingIds)
+	}
+	if err = crud.CheckTreeCycle(id, parentId); err != nil {
+		return crud.ErrorResponse(err)
+	}
+	var parentValue interface{}
+	if parentId != "" {
+		parentValue = parentId
+	}
+	var getRes mcresponse.ResponseMessage
+	if crud.LogUpdate {
+		// get current record
+		getRes = crud.GetById(modelRef, id)
+	}
+	var movedIds []string
+	err = crud.GormDb.Transaction(func(tx *gorm.DB) error {
+		txCrud := *crud
+		txCrud.GormDb = tx
+		if err := tx.Table(crud.TableName).Scopes(crud.AppScope).Where("id = ?", id).Update(options.ParentField, parentValue).Error; err != nil {
+			return DbError(err, "updateError")
+		}
+		if err := txCrud.UpdateTreePaths([]string{id}); err != nil {
+			return DbError(err, "updateError")
+		}
+		descendantIds, err := txCrud.TreeNodeIds(TreeDescendants, id)
+		if err != nil {
+			return DbError(err, "readError")
+		}
+		movedIds = append([]string{id}, descendantIds...)
+		return nil
+	})
+	if err != nil {
+		return crud.ErrorResponse(err)
+	}
+	// LogUpdate
+	var logRes mcresponse.ResponseMessage
+	if crud.LogUpdate {
+		logRes, err = crud.TransLog.AuditLog(CrudTasks().Update, crud.UserInfo.UserId, AuditLogOptionsType{
+			LogRecords:    getRes.Value,
+			NewLogRecords: map[string]interface{}{"id": []string{id}, "record": map[string]interface{}{options.ParentField: parentValue}},
+			TableName:     crud.TableName,
+			AppId:         crud.AppParams.AppId,
+			RequestMeta:   crud.RequestMeta,
+			RecordIds:     movedIds,
+		})
+		if err != nil {
+			logRes = mcresponse.ResponseMessage{
+				Code:    "logError",
+				Message: fmt.Sprintf("Audit-log error: %v", err.Error()),
+				Value:   nil,
+			}
+		}
+	}
+	// record history
+	var historyRes mcresponse.ResponseMessage
+	if crud.HistoryEnabled() {
+		historyRes = crud.SaveHistory(CrudTasks().Update, movedIds)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Task completed successfully",
+		Value: CrudResultType{
+			RecordIds:   movedIds,
+			RecordCount: len(movedIds),
+			LogRes:      logRes,
+			HistoryRes:  historyRes,
+			TaskType:    CrudTasks().Update,
+		},
+	})
+}
+
+// TreeDeleteCrud method checks the delete records (by recordIds or queryParams) for the child-records: subItems
+// response for the block delete-mode, or the crud-instance deleting the records and their descendants (by the
+// recordIds), for the cascade delete-mode
+func (crud *Crud) TreeDeleteCrud(modelRef interface{}) (*Crud, mcresponse.ResponseMessage) {
+	successRes := mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Tree check completed successfully",
+		Value:   nil,
+	})
+	ids, err := crud.UpdateRecordIds(modelRef)
+	if err != nil {
+		return nil, crud.DbErrorMessage(err, "readError")
+	}
+	if len(ids) < 1 {
+		return crud, successRes
+	}
+	options := crud.TreeOptions()
+	if options.DeleteMode == TreeDeleteCascade {
+		deleteIds := append([]string{}, ids...)
+		for _, id := range ids {
+			descendantIds, dErr := crud.TreeNodeIds(TreeDescendants, id)
+			if dErr != nil {
+				return nil, crud.DbErrorMessage(dErr, "readError")
+			}
+			for _, descendantId := range descendantIds {
+				if !ArrayStringContains(deleteIds, descendantId) {
+					deleteIds = append(deleteIds, descendantId)
+				}
+			}
+		}
+		treeCrud := *crud
+		treeCrud.RecordIds = deleteIds
+		treeCrud.QueryParams = nil
+		return &treeCrud, successRes
+	}
+	var childIds []string
+	result := crud.GormDb.Table(crud.TableName).Scopes(crud.AppScope).
+		Where(fmt.Sprintf("%v IN ? AND id NOT IN ?", options.ParentField), ids, ids).Pluck("id", &childIds)
+	if result.Error != nil {
+		return nil, crud.DbErrorMessage(result.Error, "readError")
+	}
+	if len(childIds) > 0 {
+		return nil, mcresponse.GetResMessage("subItems", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Remove error: %v child-record(s) of the delete record(s) must be removed/moved first", len(childIds)),
+			Value:   childIds,
+		})
+	}
+	return crud, successRes
+}
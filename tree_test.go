@@ -0,0 +1,111 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: tree/hierarchy operations test cases
+
+package mcgorm
+
+import (
+	"fmt"
+	"github.com/abbeymart/mctest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	treeTables := map[string]TreeOptionsType{CategoryTable: {PathField: "path", PathKeyField: "name"}}
+	mctest.McTest(mctest.OptionValue{
+		Name: "should enable the tree-table and set the tree options defaults:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: CategoryTable}, CrudOptionsType{TreeTables: treeTables})
+			mctest.AssertEquals(t, crud.TreeEnabled(), true, "categories tree should be: true")
+			options := crud.TreeOptions()
+			mctest.AssertEquals(t, options.ParentField, "parent_id", "parent-field should be: parent_id")
+			mctest.AssertEquals(t, options.PathKeyField, "name", "path-key field should be: name")
+			mctest.AssertEquals(t, options.PathSeparator, "/", "path-separator should be: /")
+			mctest.AssertEquals(t, options.DeleteMode, TreeDeleteBlock, "delete-mode should be: block")
+			mctest.AssertEquals(t, options.MaxDepth, 100, "max-depth should be: 100")
+			crud.TableName = GroupTable
+			mctest.AssertEquals(t, crud.TreeEnabled(), false, "groups tree should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the materialized paths:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, ComposeTreePath("", "books", "/"), "/books", "root path should be: /books")
+			mctest.AssertEquals(t, ComposeTreePath("/books", "fiction", "/"), "/books/fiction", "child path should be: /books/fiction")
+			mctest.AssertEquals(t, ComposeTreePath("/books/", "fiction", "/"), "/books/fiction", "trailing-separator path should be: /books/fiction")
+			parentId := "id-100"
+			mctest.AssertEquals(t, treeValue(&parentId), "id-100", "pointer value should be: id-100")
+			mctest.AssertEquals(t, treeValue(nil), "", "nil value should be: empty")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compose the app-scoped recursive descendants/ancestors queries:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: CategoryTable, AppParams: AppParamsType{AppId: "app-100"}}, CrudOptionsType{TreeTables: treeTables})
+			query, values, err := crud.TreeQuery(TreeDescendants, "id-100")
+			mctest.AssertEquals(t, err, nil, "descendants-query error should be: nil")
+			mctest.AssertEquals(t, strings.HasPrefix(query, "WITH RECURSIVE tree_nodes AS"), true, "descendants-query should be: recursive")
+			mctest.AssertEquals(t, strings.Contains(query, "ON t.parent_id = tn.id"), true, "descendants-join should be: by parent_id")
+			mctest.AssertEquals(t, strings.HasSuffix(query, "ORDER BY tree_depth ASC"), true, "descendants order should be: depth asc")
+			mctest.AssertEquals(t, len(values), 4, "descendants-query values should be: 4")
+			mctest.AssertEquals(t, values[2], 100, "third value should be: max-depth")
+			query, _, err = crud.TreeQuery(TreeAncestors, "id-100")
+			mctest.AssertEquals(t, err, nil, "ancestors-query error should be: nil")
+			mctest.AssertEquals(t, strings.Contains(query, "ON t.id = tn.parent_id"), true, "ancestors-join should be: by parent id")
+			mctest.AssertEquals(t, strings.HasSuffix(query, "ORDER BY tree_depth DESC"), true, "ancestors order should be: root first")
+			_, _, err = crud.TreeQuery("siblings", "id-100")
+			mctest.AssertEquals(t, err != nil, true, "invalid-direction error should be: not nil")
+			crud.TreeTables = map[string]TreeOptionsType{CategoryTable: {ParentField: "parent_id; DROP TABLE categories"}}
+			_, _, err = crud.TreeQuery(TreeDescendants, "id-100")
+			mctest.AssertEquals(t, err != nil, true, "invalid-field error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the self-parent tree-cycle:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: CategoryTable}, CrudOptionsType{TreeTables: treeTables})
+			err := crud.CheckTreeCycle("id-100", "id-100")
+			mctest.AssertEquals(t, err != nil, true, "self-parent error should be: not nil")
+			mctest.AssertEquals(t, ErrorToResponse(err).Code, "paramsError", "self-parent code should be: paramsError")
+			mctest.AssertEquals(t, crud.CheckTreeCycle("id-100", ""), nil, "root-node error should be: nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should walk the ancestors to the root node, beyond the max-depth, for the tree-cycle:",
+		TestFunc: func() {
+			// id-0 (root) <- id-1 <- ... <- id-150
+			parentOf := func(nodeId string) (string, error) {
+				index, _ := strconv.Atoi(strings.TrimPrefix(nodeId, "id-"))
+				if index == 0 {
+					return "", nil
+				}
+				return fmt.Sprintf("id-%v", index-1), nil
+			}
+			err := checkTreeCycle("id-0", "id-150", parentOf)
+			mctest.AssertEquals(t, err != nil, true, "deep descendant-parent error should be: not nil")
+			mctest.AssertEquals(t, ErrorToResponse(err).Code, "paramsError", "deep descendant-parent code should be: paramsError")
+			mctest.AssertEquals(t, checkTreeCycle("id-200", "id-150", parentOf), nil, "non-descendant parent error should be: nil")
+			// existing cycle (id-a <-> id-b), without the node
+			cycleOf := func(nodeId string) (string, error) {
+				return map[string]string{"id-a": "id-b", "id-b": "id-a"}[nodeId], nil
+			}
+			mctest.AssertEquals(t, checkTreeCycle("id-100", "id-a", cycleOf), nil, "existing-cycle walk error should be: nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should move the node of the hook-modified move-record:",
+		TestFunc: func() {
+			moveRecs := []interface{}{map[string]interface{}{"id": "id-200", "parent_id": "id-300"}}
+			id, parentId := treeMoveRecord(moveRecs, "parent_id", "id-100", "id-150")
+			mctest.AssertEquals(t, id, "id-200", "move id should be: id-200")
+			mctest.AssertEquals(t, parentId, "id-300", "move parent-id should be: id-300")
+			moveRecs = []interface{}{map[string]interface{}{"id": "id-100", "parent_id": nil}}
+			_, parentId = treeMoveRecord(moveRecs, "parent_id", "id-100", "id-150")
+			mctest.AssertEquals(t, parentId, "", "root move parent-id should be: empty")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -307,3 +307,105 @@ func MapToStruct(mapRecord map[string]interface{}, rec interface{}) (interface{}
 	}
 	return rec, nil
 }
+
+// SetRecordsField sets the fieldName value of a struct/*struct record, or a slice of struct-records,
+// and returns the updated record(s). If onlyZero is true, non-zero field-values are retained.
+func SetRecordsField(recs interface{}, fieldName string, value interface{}, onlyZero bool) (interface{}, error) {
+	if recs == nil {
+		return nil, errors.New("recs parameter is required")
+	}
+	setField := func(recValue reflect.Value) error {
+		field := recValue.FieldByName(fieldName)
+		if !field.IsValid() || !field.CanSet() {
+			return errors.New(fmt.Sprintf("field %v not found or not settable, for record-type: %v", fieldName, recValue.Type()))
+		}
+		if onlyZero && !field.IsZero() {
+			return nil
+		}
+		fieldValue := reflect.ValueOf(value)
+		if !fieldValue.Type().ConvertibleTo(field.Type()) {
+			return errors.New(fmt.Sprintf("value-type %v is not assignable to field %v (%v)", fieldValue.Type(), fieldName, field.Type()))
+		}
+		field.Set(fieldValue.Convert(field.Type()))
+		return nil
+	}
+	v := reflect.ValueOf(recs)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return nil, errors.New("rec parameter must be a non-nil pointer to struct{}")
+		}
+		// update in-place
+		if err := setField(v.Elem()); err != nil {
+			return nil, err
+		}
+		return recs, nil
+	case reflect.Struct:
+		// update a copy of the record
+		recValue := reflect.New(v.Type()).Elem()
+		recValue.Set(v)
+		if err := setField(recValue); err != nil {
+			return nil, err
+		}
+		return recValue.Interface(), nil
+	case reflect.Slice:
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			if item.Kind() == reflect.Interface {
+				item = item.Elem()
+			}
+			if !item.IsValid() {
+				return nil, errors.New(fmt.Sprintf("recs[%v] parameter must be of type struct{}", i))
+			}
+			rec, err := SetRecordsField(item.Interface(), fieldName, value, onlyZero)
+			if err != nil {
+				return nil, err
+			}
+			result.Index(i).Set(reflect.ValueOf(rec))
+		}
+		return result.Interface(), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("recs parameter must be of type struct{} or []struct{}: %v", v.Kind()))
+	}
+}
+
+// toJsonMaps transforms the record(s) - struct, map, slice of struct/map or GetResultType - into json-maps
+func toJsonMaps(recs interface{}) []map[string]interface{} {
+	if getResult, ok := recs.(GetResultType); ok {
+		recs = getResult.Records
+	}
+	var result []map[string]interface{}
+	jByte, err := json.Marshal(recs)
+	if err != nil {
+		return result
+	}
+	var value interface{}
+	if err = json.Unmarshal(jByte, &value); err != nil {
+		return result
+	}
+	var items []interface{}
+	switch val := value.(type) {
+	case []interface{}:
+		items = val
+	default:
+		items = []interface{}{val}
+	}
+	for _, item := range items {
+		if mapItem, ok := item.(map[string]interface{}); ok {
+			result = append(result, mapItem)
+		}
+	}
+	return result
+}
+
+// RecordIdsFrom returns the id field-values of the record(s): struct, map, slice of struct/map, or GetResultType
+func RecordIdsFrom(recs interface{}) []string {
+	var recordIds []string
+	for _, rec := range toJsonMaps(recs) {
+		if id, ok := rec["id"].(string); ok && id != "" {
+			recordIds = append(recordIds, id)
+		}
+	}
+	return recordIds
+}
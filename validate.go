@@ -0,0 +1,197 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: go: mConnect - struct-tag (validate) record validation
+
+package mcgorm
+
+import (
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateTag is the struct-tag for the record validation rules, e.g.
+// `validate:"required,min=3,max=50"`, `validate:"range=1:10"`, `validate:"email"`, `validate:"url"`,
+// `validate:"enum=draft|published"`, `validate:"regex=^[A-Z][a-z]+$"`.
+// min/max apply to the length of string/slice/map fields and to the value of the number fields.
+// The regex rule must be the last rule, the rest of the tag-value is the regex pattern.
+const ValidateTag = "validate"
+
+// ValidateRecords validates the record(s) - struct, pointer to struct or slice of struct - by the validate-tags,
+// and collects all the field errors. The field errors of the slice records are keyed by record index, e.g. [1].name
+func ValidateRecords(recs interface{}) ValidateResponseType {
+	fieldErrors := MessageObject{}
+	if recs == nil {
+		return ValidateResponseType{Ok: true, Errors: fieldErrors}
+	}
+	recsValue := reflect.Indirect(reflect.ValueOf(recs))
+	switch recsValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < recsValue.Len(); i++ {
+			validateStruct(recsValue.Index(i), fmt.Sprintf("[%v].", i), fieldErrors)
+		}
+	default:
+		validateStruct(recsValue, "", fieldErrors)
+	}
+	return ValidateResponseType{Ok: len(fieldErrors) == 0, Errors: fieldErrors}
+}
+
+// validateStruct validates the struct fields (including the embedded struct fields), by the validate-tags
+func validateStruct(recValue reflect.Value, keyPrefix string, fieldErrors MessageObject) {
+	for recValue.Kind() == reflect.Ptr || recValue.Kind() == reflect.Interface {
+		if recValue.IsNil() {
+			return
+		}
+		recValue = recValue.Elem()
+	}
+	if recValue.Kind() != reflect.Struct {
+		return
+	}
+	recType := recValue.Type()
+	for i := 0; i < recType.NumField(); i++ {
+		field := recType.Field(i)
+		if field.Anonymous {
+			validateStruct(recValue.Field(i), keyPrefix, fieldErrors)
+			continue
+		}
+		tag := field.Tag.Get(ValidateTag)
+		if tag == "" || field.PkgPath != "" {
+			continue
+		}
+		fieldName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if fieldName == "" || fieldName == "-" {
+			fieldName = field.Name
+		}
+		if messages := validateField(recValue.Field(i), tag); len(messages) > 0 {
+			fieldErrors[keyPrefix+fieldName] = strings.Join(messages, "; ")
+		}
+	}
+}
+
+// validateField returns the rule-errors for the field value
+func validateField(fieldValue reflect.Value, tag string) []string {
+	var messages []string
+	// optional (nil-pointer or empty-string) fields are validated by the required rule only; the other zero-values
+	// (e.g. 0 numbers) are validated by all the rules
+	isZero := fieldValue.IsZero()
+	for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+		fieldValue = fieldValue.Elem()
+	}
+	isEmpty := (fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil()) || (fieldValue.Kind() == reflect.String && fieldValue.Len() == 0)
+	rules := strings.Split(tag, ",")
+	for i := 0; i < len(rules); i++ {
+		rule := strings.TrimSpace(rules[i])
+		ruleName, ruleValue := rule, ""
+		if index := strings.Index(rule, "="); index >= 0 {
+			ruleName, ruleValue = rule[:index], rule[index+1:]
+		}
+		if ruleName == "regex" {
+			// the rest of the tag-value is the regex pattern
+			ruleValue = strings.Join(append([]string{ruleValue}, rules[i+1:]...), ",")
+			i = len(rules)
+		}
+		if ruleName == "required" {
+			if isZero {
+				messages = append(messages, "is required")
+			}
+			continue
+		}
+		if isEmpty {
+			continue
+		}
+		if message := validateRule(fieldValue, ruleName, ruleValue); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// validateRule returns the rule-error for the (non-empty) field value, or "" if valid
+func validateRule(fieldValue reflect.Value, ruleName string, ruleValue string) string {
+	switch ruleName {
+	case "min", "max":
+		limit, err := strconv.ParseFloat(ruleValue, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid %v rule-value: %v", ruleName, ruleValue)
+		}
+		size, isLength, ok := fieldSize(fieldValue)
+		if !ok {
+			return fmt.Sprintf("%v rule is not supported for the field type: %v", ruleName, fieldValue.Kind())
+		}
+		sizeName := "value"
+		if isLength {
+			sizeName = "length"
+		}
+		if ruleName == "min" && size < limit {
+			return fmt.Sprintf("%v must be at least %v", sizeName, ruleValue)
+		}
+		if ruleName == "max" && size > limit {
+			return fmt.Sprintf("%v must be at most %v", sizeName, ruleValue)
+		}
+	case "range":
+		limits := strings.Split(ruleValue, ":")
+		if len(limits) != 2 {
+			return fmt.Sprintf("invalid range rule-value: %v", ruleValue)
+		}
+		minValue, minErr := strconv.ParseFloat(limits[0], 64)
+		maxValue, maxErr := strconv.ParseFloat(limits[1], 64)
+		if minErr != nil || maxErr != nil {
+			return fmt.Sprintf("invalid range rule-value: %v", ruleValue)
+		}
+		size, _, ok := fieldSize(fieldValue)
+		if !ok {
+			return fmt.Sprintf("range rule is not supported for the field type: %v", fieldValue.Kind())
+		}
+		if size < minValue || size > maxValue {
+			return fmt.Sprintf("must be in the range %v to %v", limits[0], limits[1])
+		}
+	case "email":
+		if !govalidator.IsEmail(fmt.Sprintf("%v", fieldValue.Interface())) {
+			return "must be a valid email"
+		}
+	case "url":
+		if !govalidator.IsURL(fmt.Sprintf("%v", fieldValue.Interface())) {
+			return "must be a valid url"
+		}
+	case "enum":
+		allowed := strings.Split(ruleValue, "|")
+		if !ArrayStringContains(allowed, fmt.Sprintf("%v", fieldValue.Interface())) {
+			return fmt.Sprintf("must be one of: %v", strings.Join(allowed, ", "))
+		}
+	case "regex":
+		pattern, err := regexp.Compile(ruleValue)
+		if err != nil {
+			return fmt.Sprintf("invalid regex rule-value: %v", ruleValue)
+		}
+		if !pattern.MatchString(fmt.Sprintf("%v", fieldValue.Interface())) {
+			return fmt.Sprintf("must match the pattern: %v", ruleValue)
+		}
+	case "":
+		break
+	default:
+		return fmt.Sprintf("unknown validate rule: %v", ruleName)
+	}
+	return ""
+}
+
+// fieldSize returns the length (string, slice, map) or the value (number) of the field, for the min/max/range rules
+func fieldSize(fieldValue reflect.Value) (size float64, isLength bool, ok bool) {
+	switch fieldValue.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(fieldValue.String())), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(fieldValue.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fieldValue.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fieldValue.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return fieldValue.Float(), false, true
+	default:
+		return 0, false, false
+	}
+}
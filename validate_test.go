@@ -0,0 +1,90 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: struct-tag record validation test cases
+
+package mcgorm
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+type ValidateTestRecord struct {
+	BaseModelType
+	Name     string   `json:"name" validate:"required,min=3,max=20"`
+	Email    string   `json:"email" validate:"required,email"`
+	Website  string   `json:"website" validate:"url"`
+	Priority int      `json:"priority" validate:"range=1:10"`
+	Status   string   `json:"status" validate:"enum=draft|published"`
+	Code     string   `json:"code" validate:"regex=^[A-Z]{2,3}[0-9]+$"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Rank     *int     `json:"rank" validate:"range=1:5"`
+}
+
+func TestValidateRecords(t *testing.T) {
+	validRec := ValidateTestRecord{
+		Name:     "Abi",
+		Email:    "abbeya1@yahoo.com",
+		Website:  "https://mconnect.biz",
+		Priority: 5,
+		Status:   "draft",
+		Code:     "AB100",
+		Tags:     []string{"go"},
+	}
+	invalidRec := ValidateTestRecord{
+		Name:     "Ab",
+		Website:  "not a url",
+		Priority: 11,
+		Status:   "archived",
+		Code:     "ab100",
+		Tags:     []string{"go", "orm", "crud"},
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should pass the valid record, and skip the optional nil-pointer and empty-string fields:",
+		TestFunc: func() {
+			res := ValidateRecords(validRec)
+			mctest.AssertEquals(t, res.Ok, true, "validate-ok should be: true")
+			res = ValidateRecords(&ValidateTestRecord{Name: "Abi", Email: "abbeya1@yahoo.com", Priority: 1})
+			mctest.AssertEquals(t, res.Ok, true, "validate-ok should be: true")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply the number rules to the zero numbers:",
+		TestFunc: func() {
+			rank := 0
+			res := ValidateRecords(&ValidateTestRecord{Name: "Abi", Email: "abbeya1@yahoo.com", Rank: &rank})
+			mctest.AssertEquals(t, len(res.Errors), 2, "field-errors count should be: 2")
+			mctest.AssertEquals(t, res.Errors["priority"], "must be in the range 1 to 10", "priority error should be: must be in the range 1 to 10")
+			mctest.AssertEquals(t, res.Errors["rank"], "must be in the range 1 to 5", "rank error should be: must be in the range 1 to 5")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should collect all the field errors:",
+		TestFunc: func() {
+			res := ValidateRecords(invalidRec)
+			mctest.AssertEquals(t, res.Ok, false, "validate-ok should be: false")
+			mctest.AssertEquals(t, len(res.Errors), 7, "field-errors count should be: 7")
+			mctest.AssertEquals(t, res.Errors["name"], "length must be at least 3", "name error should be: length must be at least 3")
+			mctest.AssertEquals(t, res.Errors["email"], "is required", "email error should be: is required")
+			mctest.AssertEquals(t, res.Errors["priority"], "must be in the range 1 to 10", "priority error should be: must be in the range 1 to 10")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should key the field errors by record index, for the slice records:",
+		TestFunc: func() {
+			res := ValidateRecords([]interface{}{validRec, invalidRec})
+			mctest.AssertEquals(t, res.Errors["[1].status"], "must be one of: draft, published", "status error should be: must be one of: draft, published")
+			mctest.AssertEquals(t, res.Errors["[0].status"], "", "no errors for the first record")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the paramsError response:",
+		TestFunc: func() {
+			res := GetParamsMessage(ValidateRecords(invalidRec).Errors, "paramsError")
+			mctest.AssertEquals(t, res.Code, "paramsError", "response-code should be: paramsError")
+		},
+	})
+
+	mctest.PostTestResult()
+}